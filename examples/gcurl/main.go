@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os"
 	"regexp"
 
 	"github.com/StevenCyb/GoCLI/pkg/cli"
@@ -67,21 +66,8 @@ func main() {
 			cli.Example("cli get http://example.com"),
 		),
 		// ...
-		cli.Command(
-			"version", cli.Handler(
-				func(_ *cli.Context) error {
-					fmt.Println("1.0.0")
-					return nil
-				},
-			),
-			cli.Description("Get the version of the CLI"),
-		),
+		cli.VersionCommand(),
 	)
 
-	_, err := c.RunWith(os.Args)
-	if err != nil {
-		fmt.Println(err)
-		c.PrintHelp()
-		os.Exit(1)
-	}
+	c.MustRun()
 }
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/StevenCyb/GoCLI/pkg/cli"
 )
@@ -22,10 +21,5 @@ func main() {
 		}),
 	)
 
-	_, err := c.RunWith(os.Args)
-	if err != nil {
-		fmt.Println(err)
-		c.PrintHelp()
-		os.Exit(1)
-	}
+	c.MustRun()
 }
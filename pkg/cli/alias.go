@@ -0,0 +1,297 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// aliasParameter matches the placeholders $1, $2... in an alias.
+var aliasParameter = regexp.MustCompile(`\$([0-9]+)`)
+
+// AliasFileError is returned for a line of the alias file that is not of
+// the form name = "expansion".
+type AliasFileError struct {
+	Path string
+	Line int
+}
+
+func (e *AliasFileError) Error() string {
+	return "invalid alias in " + e.Path + " on line " + strconv.Itoa(e.Line)
+}
+
+// readAliases reads the aliases from the alias file. A missing file holds
+// no aliases. Invalid lines are skipped and returned as *AliasFileError,
+// joined, along with the valid aliases.
+func (c *CLI) readAliases() (map[string]string, error) {
+	aliases := map[string]string{}
+
+	file, err := os.Open(*c.aliasFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return aliases, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	invalid := []error{}
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		name, expansion, ok := parseAliasLine(scanner.Text())
+		if !ok {
+			invalid = append(invalid, &AliasFileError{Path: *c.aliasFile, Line: n})
+		} else if name != "" {
+			aliases[name] = expansion
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return aliases, errors.Join(invalid...)
+}
+
+// parseAliasLine parses a line of the alias file. It reports false if the
+// line is invalid, empty lines and comments have an empty name.
+func parseAliasLine(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", true
+	}
+
+	name, expansion, found := strings.Cut(line, "=")
+	name, expansion = strings.TrimSpace(name), strings.TrimSpace(expansion)
+	var unquoteErr error
+	if strings.HasPrefix(expansion, `"`) {
+		expansion, unquoteErr = strconv.Unquote(expansion)
+	}
+	if !found || unquoteErr != nil || validateAliasName(name) != nil {
+		return "", "", false
+	}
+
+	return name, expansion, true
+}
+
+// loadAliases is readAliases for running a command. Invalid lines are
+// reported on stderr and skipped, so the alias commands can still fix the
+// file. Only failing to read the file is an error.
+func (c *CLI) loadAliases() (map[string]string, error) {
+	aliases, err := c.readAliases()
+	var fileErr *AliasFileError
+	if errors.As(err, &fileErr) {
+		c.printError(err, ColorAuto)
+		return aliases, nil
+	}
+
+	return aliases, err
+}
+
+// writeAlias sets the alias name to expansion in the alias file, or
+// removes it if expansion is nil. Only the lines defining name change,
+// comments and invalid lines are kept.
+func (c *CLI) writeAlias(name string, expansion *string) error {
+	content, err := os.ReadFile(*c.aliasFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	lines := []string{}
+	if len(content) > 0 {
+		lines = strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	}
+	kept := []string{}
+	for _, line := range lines {
+		if lineName, _, ok := parseAliasLine(line); ok && lineName == name {
+			if expansion != nil {
+				kept = append(kept, name+" = "+strconv.Quote(*expansion))
+				expansion = nil
+			}
+			continue
+		}
+		kept = append(kept, line)
+	}
+	if expansion != nil {
+		kept = append(kept, name+" = "+strconv.Quote(*expansion))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(*c.aliasFile), 0o755); err != nil {
+		return err
+	}
+
+	content = nil
+	for _, line := range kept {
+		content = append(content, line+"\n"...)
+	}
+
+	return os.WriteFile(*c.aliasFile, content, 0o644)
+}
+
+func validateAliasName(name string) error {
+	if name == "" || strings.HasPrefix(name, "-") || strings.HasPrefix(name, "@") ||
+		strings.ContainsAny(name, " \t=\"'#") {
+		return &InvalidValueError{on: "name", value: name}
+	}
+
+	return nil
+}
+
+// expandAliases replaces an alias at the start of args by its expansion.
+// The placeholders $1, $2... are replaced by the arguments following the
+// alias, the arguments not used by a placeholder are appended. The
+// expansion may start with another alias. Aliases named like a command
+// are ignored. origin holds, for each expanded argument, the index of the
+// argument of args it stems from.
+func (c *CLI) expandAliases(args []string) (expanded []string, origin []int, err error) {
+	origin = make([]int, len(args))
+	for i := range origin {
+		origin[i] = i
+	}
+	// Only read the file if the first argument can be an alias.
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") || c.hasCommand(args[0]) {
+		return args, origin, nil
+	}
+
+	aliases, err := c.loadAliases()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	seen := map[string]bool{}
+	for len(args) > 0 {
+		name := args[0]
+		expansion, ok := aliases[name]
+		if !ok || seen[name] || c.hasCommand(name) {
+			break
+		}
+		seen[name] = true
+
+		tokens, err := SplitArgs(expansion)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		params, used := args[1:], make([]bool, len(args)-1)
+		nextArgs, nextOrigin := []string{}, []int{}
+		for _, token := range tokens {
+			tokenOrigin := origin[0]
+			var missing string
+			token = aliasParameter.ReplaceAllStringFunc(token, func(placeholder string) string {
+				n, _ := strconv.Atoi(placeholder[1:])
+				if n < 1 || n > len(params) {
+					missing = placeholder
+					return placeholder
+				}
+				used[n-1] = true
+				if placeholder == token {
+					tokenOrigin = origin[n]
+				}
+
+				return params[n-1]
+			})
+			if missing != "" {
+				return nil, nil, &ParseError{
+					Index:    len(origin),
+					Expected: "argument " + missing + " of alias " + name,
+					Err:      ErrUnexpectedEndCommand,
+				}
+			}
+			nextArgs = append(nextArgs, token)
+			nextOrigin = append(nextOrigin, tokenOrigin)
+		}
+		for i, param := range params {
+			if !used[i] {
+				nextArgs = append(nextArgs, param)
+				nextOrigin = append(nextOrigin, origin[i+1])
+			}
+		}
+
+		args, origin = nextArgs, nextOrigin
+	}
+
+	return args, origin, nil
+}
+
+// aliasNames returns the names of the aliases that are not shadowed by a
+// command, sorted.
+func (c *CLI) aliasNames(aliases map[string]string) []string {
+	names := []string{}
+	for _, name := range sortedKeys(aliases) {
+		if !c.hasCommand(name) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+func (c *CLI) aliasCommand() *command {
+	return Command("alias",
+		Description("Manage command aliases"),
+		Command("set",
+			Description("Define an alias, $1, $2... in the expansion are replaced by the arguments following the alias"),
+			Example(`alias set co "checkout --force"`),
+			Argument("name", Argument("expansion", Handler(func(ctx *Context) error {
+				name, expansion := *ctx.GetArgument("name"), *ctx.GetArgument("expansion")
+				if err := validateAliasName(name); err != nil {
+					return err
+				}
+				if c.hasCommand(name) {
+					return AliasShadowsCommandError(name)
+				}
+				if _, err := SplitArgs(expansion); err != nil {
+					return err
+				}
+
+				return c.writeAlias(name, &expansion)
+			}))),
+		),
+		Command("list",
+			Description("List the aliases"),
+			Handler(func(ctx *Context) error {
+				aliases, err := c.loadAliases()
+				if err != nil {
+					return err
+				}
+
+				sb := strings.Builder{}
+				for _, name := range c.aliasNames(aliases) {
+					sb.WriteString(name + " = " + aliases[name] + "\n")
+				}
+				_, err = io.WriteString(c.stdout, sb.String())
+
+				return err
+			}),
+		),
+		Command("remove",
+			Description("Remove an alias"),
+			Argument("name", Handler(func(ctx *Context) error {
+				name := *ctx.GetArgument("name")
+				aliases, err := c.loadAliases()
+				if err != nil {
+					return err
+				}
+				if _, ok := aliases[name]; !ok {
+					return UnknownAliasError(name)
+				}
+
+				return c.writeAlias(name, nil)
+			})),
+		),
+	)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	newCLI := func(t *testing.T, content string) (*CLI, string, *bytes.Buffer) {
+		path := filepath.Join(t.TempDir(), "config", "aliases")
+		if content != "" {
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		}
+		stdout := &bytes.Buffer{}
+
+		return New(
+			Name("git"),
+			Stream(stdout, &bytes.Buffer{}),
+			Aliases(path),
+			Command("checkout", Argument("branch",
+				Option("force"),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+			Command("log",
+				Option("oneline"),
+				Option("limit", Validate(regexp.MustCompile(`^[0-9]+$`))),
+				Handler(func(ctx *Context) error { return nil })),
+			Command("reset", Confirm("Reset?"), Handler(func(ctx *Context) error { return nil })),
+		), path, stdout
+	}
+
+	t.Run("Expand", func(t *testing.T) {
+		t.Parallel()
+
+		c, _, _ := newCLI(t, "# shortcuts\nco = \"checkout $1 --force\"\nlg = log --oneline\n")
+
+		ctx, err := c.RunWith([]string{"git", "co", "main"})
+		require.NoError(t, err)
+		assert.Equal(t, "main", *ctx.GetArgument("branch"))
+		assert.True(t, ctx.UsedOption("force"))
+
+		ctx, err = c.RunWith([]string{"git", "lg"})
+		require.NoError(t, err)
+		assert.True(t, ctx.UsedOption("oneline"))
+	})
+
+	t.Run("Nested", func(t *testing.T) {
+		t.Parallel()
+
+		c, _, _ := newCLI(t, "c = co\nco = \"checkout $1 --force\"\nloop = loop\n")
+
+		ctx, err := c.RunWith([]string{"git", "c", "main"})
+		require.NoError(t, err)
+		assert.Equal(t, "main", *ctx.GetArgument("branch"))
+		assert.True(t, ctx.UsedOption("force"))
+
+		_, err = c.RunWith([]string{"git", "loop"})
+		assert.ErrorIs(t, err, UnknownCommandError("loop"))
+	})
+
+	t.Run("ShadowedCommand", func(t *testing.T) {
+		t.Parallel()
+
+		c, _, _ := newCLI(t, "log = checkout main\n")
+
+		ctx, err := c.RunWith([]string{"git", "log"})
+		require.NoError(t, err)
+		assert.True(t, ctx.VisitedCommand("log"))
+	})
+
+	t.Run("ErrorIndex", func(t *testing.T) {
+		t.Parallel()
+
+		c, _, _ := newCLI(t, "last = \"log --limit $1\"\nlx = log --limit x\n")
+
+		_, err := c.RunWith([]string{"git", "--no-input", "last", "x"})
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 3, parseErr.Index)
+
+		_, err = c.RunWith([]string{"git", "lx"})
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 1, parseErr.Index)
+
+		_, err = c.RunWith([]string{"git", "last"})
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 2, parseErr.Index)
+		assert.Equal(t, "argument $1 of alias last", parseErr.Expected)
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		t.Parallel()
+
+		c, path, stdout := newCLI(t, "co = checkout\nbroken\n")
+		stderr := &bytes.Buffer{}
+		c.Configure(Stream(stdout, stderr))
+
+		_, err := c.RunWith([]string{"git", "log"})
+		require.NoError(t, err)
+		assert.Empty(t, stderr.String())
+
+		_, err = c.RunWith([]string{"git", "--help"})
+		assert.ErrorIs(t, err, ErrHelp)
+		assert.Contains(t, stdout.String(), "Aliases")
+		assert.Empty(t, stderr.String())
+
+		ctx, err := c.RunWith([]string{"git", "co", "main"})
+		require.NoError(t, err)
+		assert.Equal(t, "main", *ctx.GetArgument("branch"))
+		assert.Equal(t, "invalid alias in "+path+" on line 2\n", stderr.String())
+
+		aliases, err := c.readAliases()
+		assert.Equal(t, map[string]string{"co": "checkout"}, aliases)
+		var fileErr *AliasFileError
+		require.ErrorAs(t, err, &fileErr)
+		assert.Equal(t, &AliasFileError{Path: path, Line: 2}, fileErr)
+
+		_, err = c.RunWith([]string{"git", "alias", "remove", "co"})
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "broken\n", string(content))
+	})
+
+	t.Run("KeepComments", func(t *testing.T) {
+		t.Parallel()
+
+		c, path, _ := newCLI(t, "# shortcuts\nco = checkout\nbroken\n\nlg = log\n")
+
+		_, err := c.RunWith([]string{"git", "alias", "set", "co", "checkout --force"})
+		require.NoError(t, err)
+		_, err = c.RunWith([]string{"git", "alias", "remove", "lg"})
+		require.NoError(t, err)
+		_, err = c.RunWith([]string{"git", "alias", "set", "l1", "log --limit 1"})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "# shortcuts\nco = \"checkout --force\"\nbroken\n\nl1 = \"log --limit 1\"\n", string(content))
+	})
+
+	t.Run("Commands", func(t *testing.T) {
+		t.Parallel()
+
+		c, path, stdout := newCLI(t, "")
+
+		_, err := c.RunWith([]string{"git", "alias", "set", "co", "checkout $1 --force"})
+		require.NoError(t, err)
+		_, err = c.RunWith([]string{"git", "alias", "set", "lg", "log --oneline"})
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "co = \"checkout $1 --force\"\nlg = \"log --oneline\"\n", string(content))
+
+		_, err = c.RunWith([]string{"git", "alias", "list"})
+		require.NoError(t, err)
+		assert.Equal(t, "co = checkout $1 --force\nlg = log --oneline\n", stdout.String())
+
+		_, err = c.RunWith([]string{"git", "alias", "remove", "lg"})
+		require.NoError(t, err)
+		_, err = c.RunWith([]string{"git", "alias", "remove", "lg"})
+		assert.ErrorIs(t, err, UnknownAliasError("lg"))
+
+		_, err = c.RunWith([]string{"git", "alias", "set", "log", "checkout"})
+		assert.ErrorIs(t, err, AliasShadowsCommandError("log"))
+		_, err = c.RunWith([]string{"git", "alias", "set", "-x", "checkout"})
+		assert.Error(t, err)
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		c, _, stdout := newCLI(t, "co = checkout --force\n")
+
+		c.PrintHelp()
+
+		assert.Contains(t, stdout.String(), "Aliases")
+		assert.Contains(t, stdout.String(), "checkout --force")
+		assert.Equal(t, []string{"checkout", "co"}, c.complete("c"))
+	})
+}
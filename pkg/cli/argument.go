@@ -13,11 +13,13 @@ type argument struct {
 	restriction.IsCommandOption
 	restriction.IsArgumentOption
 	validate *regexp.Regexp
+	prompt   bool
 
 	name        string
 	example     *string
 	description *string
 	handler     *HandlerFunc
+	hooks       hooks
 	command     []*command
 	argument    *argument
 	options     []*option
@@ -41,6 +43,10 @@ func Argument(name string, opts ...restriction.IsArgumentOption) *argument {
 			} else {
 				panic("Invalid type for Handler option")
 			}
+		case *options.PreRun:
+			a.hooks.setPreRun(v)
+		case *options.PostRun:
+			a.hooks.setPostRun(v)
 		case *command:
 			if a.argument != nil {
 				panic(MixOfArgumentAndCommandError(v.name))
@@ -60,6 +66,8 @@ func Argument(name string, opts ...restriction.IsArgumentOption) *argument {
 			a.options = append(a.options, v)
 		case *options.Validate:
 			a.validate = v.Validate
+		case *options.Prompt:
+			a.prompt = true
 		default:
 			panic("unsupported option type")
 		}
@@ -69,72 +77,76 @@ func Argument(name string, opts ...restriction.IsArgumentOption) *argument {
 }
 
 func (c *argument) call(args *utils.AdvancedArray[string], ctx *Context) error {
-	if argValue, exists := args.Next(); exists {
-
-		if argValue == "--help" || argValue == "-h" {
-			return &HelpError{on: c, backtrack: " " + c.name}
+	argValue, exists := args.Next()
+	if !exists {
+		if !c.prompt || ctx.prompter == nil {
+			return newParseError(ctx, "", args.Index(), "argument <"+c.name+">", ErrUnexpectedEndCommand)
 		}
 
-		if c.validate != nil && !c.validate.MatchString(argValue) {
-			return &InvalidValueError{
-				on:    c.name,
-				value: argValue,
-			}
+		if argValue, exists = ctx.prompter.ask(c.name, c.description, nil, c.validate, false); !exists {
+			return newParseError(ctx, "", args.Index(), "argument <"+c.name+">", ErrUnexpectedEndCommand)
 		}
-		ctx.arguments[c.name] = argValue
+	}
+
+	if argValue == "--help" || argValue == "-h" {
+		return &HelpError{on: c, backtrack: " " + c.name}
+	}
+
+	if c.validate != nil && !c.validate.MatchString(argValue) {
+		return newParseError(ctx, argValue, args.Index()-1, "value for <"+c.name+">", &InvalidValueError{
+			on:    c.name,
+			value: argValue,
+		})
+	}
+	ctx.arguments[c.name] = argValue
+	ctx.path = append(ctx.path, c.name)
+	ctx.lineage = append(ctx.lineage, &c.hooks)
 
-		if argValue == "--help" || argValue == "-h" {
-			return &HelpError{on: c, backtrack: " " + c.name}
+	if argValue == "--help" || argValue == "-h" {
+		return &HelpError{on: c, backtrack: " " + c.name}
+	}
+
+	if c.argument != nil {
+		if err := c.argument.call(args, ctx); err != nil {
+			if helpErr, ok := err.(*HelpError); ok {
+				helpErr.backtrack = " " + c.name + helpErr.backtrack
+				return helpErr
+			}
+			return err
 		}
+		return nil
+	}
 
-		if c.argument != nil {
-			if err := c.argument.call(args, ctx); err != nil {
+	if len(c.command) > 0 {
+		for _, cmd := range c.command {
+			if err := cmd.call(args, ctx); err != nil && err != ErrNotMatched {
 				if helpErr, ok := err.(*HelpError); ok {
 					helpErr.backtrack = " " + c.name + helpErr.backtrack
 					return helpErr
 				}
 				return err
+			} else if err == nil {
+				return nil
 			}
-			return nil
 		}
 
-		if len(c.command) > 0 {
-			for _, cmd := range c.command {
-				if err := cmd.call(args, ctx); err != nil && err != ErrNotMatched {
-					if helpErr, ok := err.(*HelpError); ok {
-						helpErr.backtrack = " " + c.name + helpErr.backtrack
-						return helpErr
-					}
-					return err
-				} else if err == nil {
-					return nil
-				}
-			}
-
-			arg, _ := args.Next()
-
-			return UnknownCommandError(arg)
-		}
+		arg, _ := args.Next()
 
-		for _, opt := range c.options {
-			if err := opt.call(args, ctx); err != nil && err != ErrNotMatched {
-				if helpErr, ok := err.(*HelpError); ok {
-					helpErr.on = c
-					return helpErr
-				}
+		return newParseError(ctx, arg, args.Index()-1, "command", UnknownCommandError(arg))
+	}
 
-				return err
-			}
+	if err := callOptions(c.options, args, ctx); err != nil {
+		if helpErr, ok := err.(*HelpError); ok {
+			helpErr.on = c
+			return helpErr
 		}
 
-		if c.handler != nil {
-			if err := (*c.handler)(ctx); err != nil {
-				return err
-			}
-		}
+		return err
+	}
 
-		return nil
+	if err := ctx.promptOptions(c.options, args.Index()); err != nil {
+		return err
 	}
 
-	return ErrUnexpectedEndCommand
+	return ctx.execute(&c.hooks, c.handler)
 }
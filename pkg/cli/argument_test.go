@@ -133,7 +133,7 @@ func TestArgumentCall(t *testing.T) {
 		err := arg.call(args, ctx)
 
 		assert.Error(t, err)
-		assert.Equal(t, ErrUnexpectedEndCommand, err)
+		assert.Equal(t, ErrUnexpectedEndCommand, parseErrorCause(t, err))
 	})
 
 	t.Run("MatchArgument", func(t *testing.T) {
@@ -225,7 +225,7 @@ func TestArgumentCall(t *testing.T) {
 		assert.Equal(t, &InvalidValueError{
 			on:    "test",
 			value: "invalid",
-		}, err)
+		}, parseErrorCause(t, err))
 	})
 
 	t.Run("WithOptionFailingValidation", func(t *testing.T) {
@@ -244,7 +244,7 @@ func TestArgumentCall(t *testing.T) {
 		assert.Equal(t, &InvalidValueError{
 			on:    "opt",
 			value: "value",
-		}, err)
+		}, parseErrorCause(t, err))
 	})
 
 	t.Run("WithHelpOption", func(t *testing.T) {
@@ -299,7 +299,7 @@ func TestArgumentCall(t *testing.T) {
 		err := arg.call(args, ctx)
 
 		assert.Error(t, err)
-		assert.IsType(t, UnknownCommandError(""), err)
+		assert.IsType(t, UnknownCommandError(""), parseErrorCause(t, err))
 	})
 
 	t.Run("SubCommandWithOptions", func(t *testing.T) {
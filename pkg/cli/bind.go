@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// bind sets the tagged fields of the struct v from the arguments and
+// options of the context.
+func (c *Context) bind(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		name, ok := v.Type().Field(i).Tag.Lookup("cli")
+		if !ok || name == "-" {
+			continue
+		}
+
+		raw, exists := c.arguments[name]
+		if !exists {
+			raw, exists = c.options[name]
+		}
+		if !exists {
+			continue
+		}
+
+		if err := setField(v.Field(i), raw); err != nil {
+			if c.secrets[name] {
+				raw = redacted
+			}
+			return &InvalidValueError{on: name, value: raw}
+		}
+	}
+
+	return nil
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// setField converts raw to the type of field and sets it.
+func setField(field reflect.Value, raw string) error {
+	if field.Kind() == reflect.Pointer {
+		value := reflect.New(field.Type().Elem())
+		if err := setField(value.Elem(), raw); err != nil {
+			return err
+		}
+		field.Set(value)
+
+		return nil
+	}
+
+	if field.Addr().Type().Implements(textUnmarshalerType) {
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw))
+	}
+
+	if field.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Bool:
+		// An option given without a value is a flag.
+		if raw == "" {
+			field.SetBool(true)
+			return nil
+		}
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	}
+
+	return nil
+}
+
+// checkInput panics if t is not a struct or has a tagged field that can
+// not be set from a string.
+func checkInput(t reflect.Type) {
+	if t.Kind() != reflect.Struct {
+		panic("Invalid type for HandlerOf input")
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name, ok := field.Tag.Lookup("cli"); !ok || name == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			panic("Unexported HandlerOf input field " + field.Name)
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+			continue
+		}
+
+		switch fieldType.Kind() {
+		case reflect.String, reflect.Bool,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			panic("Unsupported type " + field.Type.String() + " of HandlerOf input field " + field.Name)
+		}
+	}
+}
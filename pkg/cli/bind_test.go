@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlerOf(t *testing.T) {
+	t.Parallel()
+
+	type input struct {
+		Host    netip.Addr    `cli:"host"`
+		Port    uint16        `cli:"port"`
+		Retries *int          `cli:"retries"`
+		Ratio   float64       `cli:"ratio"`
+		Timeout time.Duration `cli:"timeout"`
+		Verbose bool          `cli:"verbose"`
+		Name    *string       `cli:"name"`
+		Ignored string
+	}
+
+	newCLI := func(in *input) *CLI {
+		return New(
+			Command("connect",
+				Argument("host",
+					Option("port"),
+					Option("retries"),
+					Option("ratio"),
+					Option("timeout"),
+					Option("verbose"),
+					Option("name"),
+					HandlerOf(func(ctx *Context, got input) error {
+						*in = got
+						return nil
+					}),
+				),
+			),
+		)
+	}
+
+	t.Run("Convert", func(t *testing.T) {
+		t.Parallel()
+
+		var in input
+		_, err := newCLI(&in).RunWith([]string{
+			"cli", "connect", "127.0.0.1",
+			"--port", "8080", "--retries", "3", "--ratio", "0.5", "--timeout", "2s", "--verbose",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, netip.MustParseAddr("127.0.0.1"), in.Host)
+		assert.Equal(t, uint16(8080), in.Port)
+		if assert.NotNil(t, in.Retries) {
+			assert.Equal(t, 3, *in.Retries)
+		}
+		assert.Equal(t, 0.5, in.Ratio)
+		assert.Equal(t, 2*time.Second, in.Timeout)
+		assert.True(t, in.Verbose)
+		assert.Nil(t, in.Name)
+	})
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		t.Parallel()
+
+		var in input
+		_, err := newCLI(&in).RunWith([]string{"cli", "connect", "127.0.0.1", "--port", "http"})
+
+		assert.ErrorAs(t, err, new(*InvalidValueError))
+		assert.EqualError(t, err, "invalid value for port: http")
+	})
+
+	t.Run("InvalidSecretValue", func(t *testing.T) {
+		t.Parallel()
+
+		c := New(Command("login", Option("token", Secret()), HandlerOf(func(ctx *Context, in struct {
+			Token int `cli:"token"`
+		}) error {
+			return nil
+		})))
+		_, err := c.RunWith([]string{"cli", "login", "--token", "hunter2"})
+
+		assert.EqualError(t, err, "invalid value for token: ***")
+	})
+
+	t.Run("InvalidInput", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "Invalid type for HandlerOf input", func() {
+			HandlerOf(func(ctx *Context, in string) error { return nil })
+		})
+		assert.PanicsWithValue(t, "Unsupported type []string of HandlerOf input field Names", func() {
+			HandlerOf(func(ctx *Context, in struct {
+				Names []string `cli:"names"`
+			}) error {
+				return nil
+			})
+		})
+	})
+}
@@ -1,36 +1,79 @@
 package cli
 
 import (
+	"context"
+	"errors"
 	"io"
 	"os"
-	"strings"
+	"os/signal"
+	"time"
 
 	"github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
 	"github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/term"
 	"github.com/StevenCyb/GoCLI/pkg/cli/internal/utils"
 )
 
 type CLI struct {
-	name        *string
-	version     *string
-	banner      *string
-	example     *string
-	description *string
-	stdout      io.Writer
-	stderr      io.Writer
-	handler     *HandlerFunc
-	command     []*command
-	argument    *argument
-	options     []*option
+	name            *string
+	version         *string
+	banner          *string
+	example         *string
+	description     *string
+	stdin           io.Reader
+	stdout          io.Writer
+	stderr          io.Writer
+	handler         *HandlerFunc
+	hooks           hooks
+	renderer        HelpRenderer
+	exit            func(code int)
+	getenv          func(key string) string
+	exitCodes       []exitCodeMapping
+	gracePeriod     time.Duration
+	values          map[interface{}]interface{}
+	notify          func(c chan<- os.Signal, sig ...os.Signal)
+	isTerminal      func(v interface{}) bool
+	interactive     bool
+	localeName      *string
+	catalogs        map[string]Catalog
+	theme           *Theme
+	outputDefault   *string
+	shellPrompt     *string
+	shellHistory    *string
+	batch           bool
+	plugins         bool
+	responseFiles   bool
+	aliasFile       *string
+	pluginDirs      []string
+	continueOnError bool
+	command         []*command
+	argument        *argument
+	options         []*option
 }
 
 func New(opts ...restriction.IsCliOption) *CLI {
 	cli := &CLI{
-		stdout:  os.Stdout,
-		stderr:  os.Stderr,
-		command: []*command{},
-		options: make([]*option, 0),
+		stdin:      os.Stdin,
+		stdout:     os.Stdout,
+		stderr:     os.Stderr,
+		renderer:   DefaultHelpRenderer{},
+		exit:       os.Exit,
+		notify:     signal.Notify,
+		isTerminal: term.IsTerminal,
+		getenv:     os.Getenv,
+		command:    []*command{},
+		options:    make([]*option, 0),
 	}
+	cli.Configure(opts...)
+
+	return cli
+}
+
+// Configure applies opts to the CLI like New does, e.g. to redirect the
+// streams of a CLI built elsewhere in a test. It must not be called while
+// the CLI runs.
+func (cli *CLI) Configure(opts ...restriction.IsCliOption) {
+	versionCommand, aliasCommand := false, false
 
 	for _, opt := range opts {
 		switch v := opt.(type) {
@@ -47,22 +90,95 @@ func New(opts ...restriction.IsCliOption) *CLI {
 		case *options.Stream:
 			cli.stdout = v.Stdout
 			cli.stderr = v.Stderr
+		case *options.Stdin:
+			cli.stdin = v.Stdin
+		case *options.Interactive:
+			cli.interactive = true
 		case *options.Handler:
 			if handlerFunc, ok := v.Handler.(HandlerFunc); ok {
 				cli.handler = &handlerFunc
 			} else {
 				panic("Invalid type for Handler option")
 			}
-		case *command:
-			if cli.argument != nil {
-				panic(MixOfArgumentAndCommandError(v.name))
+		case *options.ExitFunc:
+			if exit, ok := v.ExitFunc.(func(code int)); ok {
+				cli.exit = exit
+			} else {
+				panic("Invalid type for ExitFunc option")
+			}
+		case *options.Getenv:
+			if getenv, ok := v.Getenv.(func(key string) string); ok {
+				cli.getenv = getenv
+			} else {
+				panic("Invalid type for Getenv option")
 			}
-			for _, c := range cli.command {
-				if c.name == v.name {
-					panic(DuplicateCommandError(c.name))
+		case *options.ExitCode:
+			if match, ok := v.Match.(func(err error) bool); ok {
+				cli.exitCodes = append(cli.exitCodes, exitCodeMapping{match: match, code: v.Code})
+			} else {
+				panic("Invalid type for ExitCode option")
+			}
+		case *options.Output:
+			if _, err := parseOutputFormat(v.Format); err != nil {
+				panic("Invalid format for Output option: " + err.Error())
+			}
+			cli.outputDefault = &v.Format
+		case *options.ShellPrompt:
+			cli.shellPrompt = &v.Prompt
+		case *options.ShellHistory:
+			cli.shellHistory = &v.Path
+		case *options.Plugins:
+			cli.plugins = true
+			cli.pluginDirs = append(cli.pluginDirs, v.Dirs...)
+		case *options.Aliases:
+			aliasCommand = cli.aliasFile == nil
+			cli.aliasFile = &v.Path
+		case *options.ResponseFiles:
+			cli.responseFiles = true
+		case *options.Batch:
+			cli.batch = true
+		case *options.ContinueOnError:
+			cli.continueOnError = true
+		case *options.Inject:
+			if cli.values == nil {
+				cli.values = make(map[interface{}]interface{})
+			}
+			cli.values[v.Key] = v.Value
+		case *options.GracePeriod:
+			cli.gracePeriod = v.GracePeriod
+		case *options.Locale:
+			cli.localeName = &v.Locale
+		case *options.Messages:
+			if catalog, ok := v.Catalog.(Catalog); ok {
+				if cli.catalogs == nil {
+					cli.catalogs = make(map[string]Catalog)
 				}
+				cli.catalogs[v.Locale] = catalog
+			} else {
+				panic("Invalid type for Messages option")
+			}
+		case *options.Colors:
+			if theme, ok := v.Theme.(Theme); ok {
+				cli.theme = &theme
+			} else {
+				panic("Invalid type for Colors option")
+			}
+		case *options.HelpRenderer:
+			if renderer, ok := v.Renderer.(HelpRenderer); ok {
+				cli.renderer = renderer
+			} else {
+				panic("Invalid type for HelpRenderer option")
 			}
-			cli.command = append(cli.command, v)
+		case *options.VersionCommand:
+			versionCommand = true
+		case *options.PreRun:
+			cli.hooks.setPreRun(v)
+		case *options.PostRun:
+			cli.hooks.setPostRun(v)
+		case *options.Middleware:
+			cli.hooks.use(v)
+		case *command:
+			cli.addCommand(v)
 		case *argument:
 			if len(cli.command) > 0 {
 				panic(MixOfArgumentAndCommandError(v.name))
@@ -75,7 +191,24 @@ func New(opts ...restriction.IsCliOption) *CLI {
 		}
 	}
 
-	return cli
+	if versionCommand {
+		cli.addCommand(cli.versionCommand())
+	}
+	if aliasCommand {
+		cli.addCommand(cli.aliasCommand())
+	}
+}
+
+func (cli *CLI) addCommand(cmd *command) {
+	if cli.argument != nil {
+		panic(MixOfArgumentAndCommandError(cmd.name))
+	}
+	for _, c := range cli.command {
+		if c.name == cmd.name {
+			panic(DuplicateCommandError(c.name))
+		}
+	}
+	cli.command = append(cli.command, cmd)
 }
 
 func (cli *CLI) Run() (*Context, error) {
@@ -83,42 +216,223 @@ func (cli *CLI) Run() (*Context, error) {
 }
 
 func (cli *CLI) MustRun() *Context {
-	ctx, err := cli.Run()
-	if err != nil {
-		cli.stderr.Write([]byte(err.Error() + "\n"))
-		cli.PrintHelp()
-		os.Exit(1)
-	}
-
-	return ctx
+	return cli.MustRunWith(os.Args)
 }
 
+// MustRunWith runs the CLI with the given arguments and exits through the
+// configured ExitFunc if it fails, see ExitOnError.
 func (cli *CLI) MustRunWith(argsRaw []string) *Context {
 	ctx, err := cli.RunWith(argsRaw)
+	cli.ExitOnError(argsRaw, err)
+
+	return ctx
+}
+
+// ExitOnError exits through the configured ExitFunc if err, returned by
+// RunWith for argsRaw, is not nil. Requested help or version information
+// exits with ExitOK, any other error is printed along with the help and
+// exits with the code determined by the error, see ExitCoder and
+// ExitCodeFor.
+func (cli *CLI) ExitOnError(argsRaw []string, err error) {
+	if errors.Is(err, ErrHelp) || errors.Is(err, ErrVersion) {
+		cli.exit(ExitOK)
+	} else if isReported(err) {
+		cli.exit(cli.exitCode(err))
+	} else if err != nil {
+		color := cli.colorMode(argsRaw, ColorAuto)
+		cli.reportError(argsRaw, err, color)
+		cli.printHelp(nil, color)
+		cli.exit(cli.exitCode(err))
+	}
+}
+
+// RunLine is like RunWith but takes the arguments as a single command line
+// without the program name, split with SplitArgs, e.g.
+// c.RunLine(`get 'http://x y' -v`).
+func (c *CLI) RunLine(line string) (*Context, error) {
+	args, err := SplitArgs(line)
 	if err != nil {
-		cli.stderr.Write([]byte(err.Error() + "\n"))
-		cli.PrintHelp()
-		os.Exit(1)
+		return nil, err
 	}
 
-	return ctx
+	return c.RunWith(append([]string{c.Name()}, args...))
+}
+
+// reportError prints err in the color mode and, for a ParseError, the
+// diagnostic pointing at the offending argument of argv.
+func (c *CLI) reportError(argv []string, err error, color string) {
+	c.printError(err, color)
+
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		io.WriteString(c.stderr, c.diagnostic(argv, parseErr, c.translator())+"\n")
+	}
 }
 
 func (c *CLI) RunWith(argsRaw []string) (*Context, error) {
+	return c.RunContext(context.Background(), argsRaw)
+}
+
+// RunContext is like RunWith but the handler's Context carries a
+// context.Context derived from parent. It is cancelled on the first SIGINT
+// or SIGTERM, see GracePeriod, and when RunContext returns.
+func (c *CLI) RunContext(parent context.Context, argsRaw []string) (*Context, error) {
+	goCtx, cancel := context.WithCancel(parent)
+	defer cancel()
+	defer c.handleSignals(cancel)()
+
+	return c.runArgv(goCtx, nil, argsRaw)
+}
+
+// runArgv expands the response files in argsRaw and runs it with the
+// global flags given on top of base, which is nil for a top-level run.
+func (c *CLI) runArgv(goCtx context.Context, base *runFlags, argsRaw []string) (*Context, error) {
+	if !c.responseFiles {
+		return c.runExpanded(goCtx, base, argsRaw)
+	}
+
+	var parseErr *ParseError
+	expanded, origin, err := expandResponseFiles(argsRaw[1:])
+	if errors.As(err, &parseErr) {
+		parseErr.Index++
+		return nil, err
+	}
+
+	ctx, err := c.runExpanded(goCtx, base, append([]string{argsRaw[0]}, expanded...))
+
+	// Point at the argument the offending one was expanded from.
+	if errors.As(err, &parseErr) {
+		if parseErr.Index-1 < len(origin) {
+			parseErr.Index = origin[parseErr.Index-1] + 1
+		} else {
+			parseErr.Index = len(argsRaw)
+		}
+	}
+
+	return ctx, err
+}
+
+// runFlags holds the state selected by the global flags of a run. It is
+// kept out of the CLI so runs can overlap.
+type runFlags struct {
+	color    string
+	output   outputFormat
+	prompter *prompter
+}
+
+// parseGlobalFlags removes the global flags from argv and returns the
+// remaining arguments without the program name. Flags that are not given
+// keep their value from base, e.g. those given next to --batch for the
+// lines of the script.
+func (c *CLI) parseGlobalFlags(argv []string, base *runFlags) (*flagArgs, *runFlags, error) {
+	args := newFlagArgs(c.node(), argv)
+	flags := &runFlags{color: ColorAuto}
+	if base != nil {
+		*flags = *base
+	}
+
+	if c.theme != nil {
+		var err error
+		if flags.color, err = c.parseColorFlag(args, flags.color); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.outputDefault != nil {
+		var err error
+		if flags.output, err = c.parseOutputFlag(args, flags.output); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	noInput := usesPrompts(c.node()) && args.extractSwitch("no-input")
+	if noInput || base == nil {
+		flags.prompter = c.newPrompter(noInput)
+	}
+
+	return args, flags, nil
+}
+
+// runExpanded extracts the global flags from argv and runs the rest.
+func (c *CLI) runExpanded(goCtx context.Context, base *runFlags, argv []string) (*Context, error) {
+	rest, flags, err := c.parseGlobalFlags(argv, base)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.batch {
+		if path, index, found := rest.extractFlag("batch"); found && base != nil {
+			// A script running itself would never end.
+			return nil, &ParseError{
+				Token:    path,
+				Index:    index,
+				Expected: "no --batch in a script",
+				Err:      UnknownArgumentError("--batch"),
+			}
+		} else if found {
+			return nil, c.runBatch(goCtx, flags, rest, path)
+		}
+	}
+
+	var parseErr *ParseError
+	args := rest.args
+	var origin []int
+	if c.aliasFile != nil {
+		var err error
+		if args, origin, err = c.expandAliases(rest.args); err != nil {
+			if errors.As(err, &parseErr) {
+				parseErr.Index = rest.argvIndex(parseErr.Index)
+			}
+			return nil, err
+		}
+	}
+
+	ctx, err := c.run(goCtx, flags, args)
+
+	if errors.As(err, &parseErr) {
+		if origin != nil {
+			// Point at the argument the offending one was expanded from.
+			if parseErr.Index < len(origin) {
+				parseErr.Index = origin[parseErr.Index]
+			} else {
+				parseErr.Index = len(rest.args)
+			}
+		}
+		parseErr.Index = rest.argvIndex(parseErr.Index)
+	}
+
+	return ctx, err
+}
+
+// run parses args, the command line without the program name and global
+// flags, and calls the matched handler.
+func (c *CLI) run(goCtx context.Context, flags *runFlags, rest []string) (*Context, error) {
 	ctx := NewContext()
-	args := utils.NewAdvancedArray(argsRaw[1:])
+	ctx.context = goCtx
+	ctx.stdin = c.stdin
+	ctx.stdout = c.stdout
+	ctx.output = flags.output
+	ctx.prompter = flags.prompter
+	for key, value := range c.values {
+		ctx.Set(key, value)
+	}
+	ctx.lineage = append(ctx.lineage, &c.hooks)
+	args := utils.NewAdvancedArray(rest)
 
 	if argValue, exists := args.Next(); exists {
 		args.Back()
 		if argValue == "--help" || argValue == "-h" {
-			c.printHelp(nil)
+			c.printHelp(nil, flags.color)
+			return nil, &HelpError{}
+		}
+		if c.isVersionFlag(argValue) {
+			return nil, c.printVersionFlag(rest[1:])
 		}
 	}
 
 	if c.argument != nil {
 		if err := c.argument.call(args, ctx); err != nil {
 			if helpErr, ok := err.(*HelpError); ok {
-				c.printHelp(helpErr)
+				c.printHelp(helpErr, flags.color)
 			}
 			return nil, err
 		}
@@ -126,11 +440,23 @@ func (c *CLI) RunWith(argsRaw []string) (*Context, error) {
 		return ctx, nil
 	}
 
+	if c.plugins && len(rest) > 0 {
+		if path, found := c.lookupPlugin(rest[0]); found {
+			ctx.commands = append(ctx.commands, rest[0])
+			ctx.path = append(ctx.path, rest[0])
+			if err := c.runPlugin(rest[0], path, rest[1:]); err != nil {
+				return nil, err
+			}
+
+			return ctx, nil
+		}
+	}
+
 	if len(c.command) > 0 {
 		for _, cmd := range c.command {
 			if err := cmd.call(args, ctx); err != nil && err != ErrNotMatched {
 				if helpErr, ok := err.(*HelpError); ok {
-					c.printHelp(helpErr)
+					c.printHelp(helpErr, flags.color)
 				}
 				return nil, err
 			} else if err == nil {
@@ -139,125 +465,46 @@ func (c *CLI) RunWith(argsRaw []string) (*Context, error) {
 		}
 
 		if argValue, exists := args.Next(); exists {
-			return nil, UnknownCommandError(argValue)
+			return nil, newParseError(ctx, argValue, args.Index()-1, "command", UnknownCommandError(argValue))
 		}
 
-		return nil, ErrUnexpectedEndCommand
+		return nil, newParseError(ctx, "", args.Index(), "command", ErrUnexpectedEndCommand)
 	}
 
-	for _, opt := range c.options {
-		if err := opt.call(args, ctx); err != nil && err != ErrNotMatched {
-			if _, ok := err.(*HelpError); ok {
-				c.printHelp(nil)
-			}
-			return nil, err
+	if err := callOptions(c.options, args, ctx); err != nil {
+		if _, ok := err.(*HelpError); ok {
+			c.printHelp(nil, flags.color)
 		}
+		return nil, err
 	}
 
-	if c.handler != nil {
-		if err := (*c.handler)(ctx); err != nil {
-			return nil, err
-		}
+	if err := ctx.promptOptions(c.options, args.Index()); err != nil {
+		return nil, err
 	}
 
-	return ctx, nil
-}
+	if err := ctx.execute(&c.hooks, c.handler); err != nil {
+		return nil, err
+	}
 
-// PrintHelp prints the help message for the CLI application.
-// It also exits afterwards with a status code of 0.
-func (c *CLI) PrintHelp() {
-	c.printHelp(nil)
+	return ctx, nil
 }
 
-func (c *CLI) printHelp(helpError *HelpError) {
-	sb := strings.Builder{}
-	var (
-		name        = "cli"
-		backtrack   string
-		description *string
-		example     *string
-		arg         *argument
-		commands    []*command
-		options     []*option
-	)
-
+// Name returns the configured name of the CLI or "cli" if none is set.
+func (c *CLI) Name() string {
 	if c.name != nil {
-		name = *c.name
-	}
-
-	if helpError == nil || helpError.on == nil {
-		description = c.description
-		arg = c.argument
-		commands = c.command
-		options = c.options
-		example = c.example
-	} else {
-		switch v := helpError.on.(type) {
-		case *argument:
-			backtrack = helpError.backtrack
-			description = v.description
-			arg = v
-			commands = v.command
-			options = v.options
-			example = v.example
-		case *command:
-			backtrack = helpError.backtrack
-			description = v.description
-			arg = v.argument
-			commands = v.command
-			options = v.options
-			example = v.example
-		}
+		return *c.name
 	}
 
-	if c.banner != nil {
-		sb.WriteString(*c.banner + "\n\n")
-	}
-	if c.version != nil {
-		sb.WriteString(*c.version + "\n\n")
-	}
-	if description != nil {
-		sb.WriteString(*description + "\n\n")
-	}
+	return "cli"
+}
 
-	sb.WriteString("Usage: \n\t" + name + backtrack)
-	if arg != nil {
-		sb.WriteString(" <argument>")
-	} else if len(commands) > 0 {
-		sb.WriteString(" <command>\n\n")
-		sb.WriteString("Commands:\n")
-		for _, cmd := range commands {
-			sb.WriteString("\t" + cmd.name + "\n")
-			if cmd.description != nil {
-				sb.WriteString("\t\t" + *cmd.description + "\n")
-			}
-			if cmd.example != nil {
-				sb.WriteString("\t\tExample: " + *cmd.example + "\n")
-			}
-		}
-	}
-	if len(options) > 0 {
-		sb.WriteString(" [options...]\n\n")
-		sb.WriteString("Options:\n")
-		for _, opt := range options {
-			sb.WriteString("\t--" + opt.long)
-			if opt.short != nil {
-				sb.WriteString(", -" + string(*opt.short))
-			}
-			if opt.description != nil {
-				sb.WriteString("\n\t\t" + *opt.description + "\n")
-			}
-		}
-	}
+// PrintHelp prints the help message for the CLI application.
+func (c *CLI) PrintHelp() {
+	c.printHelp(nil, ColorAuto)
+}
 
-	if example != nil {
-		sb.WriteString("\n\nExample:\n")
-		sb.WriteString("\t" + *example + "\n")
+func (c *CLI) printHelp(helpError *HelpError, color string) {
+	if err := c.renderer.Render(c.stdout, c.helpData(helpError, color)); err != nil {
+		io.WriteString(c.stderr, err.Error()+"\n")
 	}
-
-	sb.WriteString("\n\nUse \"" + name + " <command> --help\" for more information about a command.\n\n")
-
-	io.WriteString(c.stdout, sb.String())
-
-	os.Exit(0)
 }
@@ -1,15 +1,28 @@
 package cli
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// newTestCLI returns a CLI with opts that reads input from stdin, which
+// counts as a terminal if terminal is set.
+func newTestCLI(input string, terminal bool, opts ...restriction.IsCliOption) *CLI {
+	cli := New(append([]restriction.IsCliOption{Stdin(strings.NewReader(input))}, opts...)...)
+	cli.isTerminal = func(v interface{}) bool { return terminal }
+
+	return cli
+}
+
 func TestCLI(t *testing.T) {
 	t.Parallel()
 
@@ -79,6 +92,21 @@ func TestCLI(t *testing.T) {
 		assert.NotNil(t, *cli.handler)
 	})
 
+	t.Run("WithHelpRenderer", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, DefaultHelpRenderer{}, New().renderer)
+
+		renderer, err := NewTemplateHelpRenderer("{{.Name}}")
+		assert.NoError(t, err)
+		cli := New(Renderer(renderer))
+		assert.Equal(t, renderer, cli.renderer)
+
+		assert.PanicsWithValue(t, "Invalid type for HelpRenderer option", func() {
+			New(&options.HelpRenderer{Renderer: "invalid"})
+		})
+	})
+
 	t.Run("WithOptions", func(t *testing.T) {
 		t.Parallel()
 		opt1 := &option{long: "opt1"}
@@ -168,7 +196,7 @@ func TestCLIRun(t *testing.T) {
 		cmd1 := Command("test")
 		cmd2 := Command("test2")
 		cli := New(cmd1, cmd2)
-		ctx, err := cli.RunWith([]string{"test2"})
+		ctx, err := cli.RunWith([]string{"cli", "test2"})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, ctx)
@@ -180,11 +208,11 @@ func TestCLIRun(t *testing.T) {
 
 		cmd := Command("test")
 		cli := New(cmd)
-		ctx, err := cli.RunWith([]string{"unknown"})
+		ctx, err := cli.RunWith([]string{"cli", "unknown"})
 
 		assert.Error(t, err)
 		assert.Nil(t, ctx)
-		assert.Equal(t, UnknownCommandError("unknown"), err)
+		assert.Equal(t, UnknownCommandError("unknown"), parseErrorCause(t, err))
 	})
 
 	t.Run("RunWithHandler", func(t *testing.T) {
@@ -222,7 +250,7 @@ func TestCLIRun(t *testing.T) {
 
 		arg := &argument{name: "arg"}
 		cli := New(arg)
-		ctx, err := cli.RunWith([]string{"test"})
+		ctx, err := cli.RunWith([]string{"cli", "test"})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, ctx)
@@ -234,7 +262,7 @@ func TestCLIRun(t *testing.T) {
 
 		opt := &option{long: "opt"}
 		cli := New(opt)
-		ctx, err := cli.RunWith([]string{"--opt", "value"})
+		ctx, err := cli.RunWith([]string{"cli", "--opt", "value"})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, ctx)
@@ -244,7 +272,7 @@ func TestCLIRun(t *testing.T) {
 	t.Run("WithOptionFailingValidation", func(t *testing.T) {
 		t.Parallel()
 
-		args := []string{"--opt", "value", "-b", "value2"}
+		args := []string{"cli", "--opt", "value", "-b", "value2"}
 		b := 'b'
 		opt1 := &option{long: "aa"}
 		opt2 := &option{long: "opt", validate: regexp.MustCompile("^v[0-9]+$")}
@@ -257,13 +285,13 @@ func TestCLIRun(t *testing.T) {
 		assert.Equal(t, &InvalidValueError{
 			on:    "opt",
 			value: "value",
-		}, err)
+		}, parseErrorCause(t, err))
 	})
 
 	t.Run("WithArgumentWithOptionFailingValidation", func(t *testing.T) {
 		t.Parallel()
 
-		args := []string{"x", "--opt", "value", "-b", "value2"}
+		args := []string{"cli", "x", "--opt", "value", "-b", "value2"}
 		b := 'b'
 		opt1 := &option{long: "aa"}
 		opt2 := &option{long: "opt", validate: regexp.MustCompile("^v[0-9]+$")}
@@ -276,6 +304,152 @@ func TestCLIRun(t *testing.T) {
 		assert.Equal(t, &InvalidValueError{
 			on:    "opt",
 			value: "value",
-		}, err)
+		}, parseErrorCause(t, err))
+	})
+
+	t.Run("ParseError", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(
+			Colors(DefaultTheme),
+			Command("get", Argument("url", Validate(regexp.MustCompile("^https?://")))),
+			Command("config", Command("set")),
+		)
+
+		for name, tc := range map[string]struct {
+			args     []string
+			expected *ParseError
+		}{
+			"UnknownCommand": {
+				[]string{"gurl", "config", "unset"},
+				&ParseError{Path: []string{"config"}, Token: "unset", Index: 2, Expected: "command", Err: UnknownCommandError("unset")},
+			},
+			"InvalidValue": {
+				[]string{"gurl", "--color=never", "get", "htp://x"},
+				&ParseError{Path: []string{"get"}, Token: "htp://x", Index: 3, Expected: "value for <url>", Err: &InvalidValueError{on: "url", value: "htp://x"}},
+			},
+			"UnexpectedEnd": {
+				[]string{"gurl", "get"},
+				&ParseError{Path: []string{"get"}, Token: "", Index: 2, Expected: "argument <url>", Err: ErrUnexpectedEndCommand},
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := cli.RunWith(tc.args)
+
+				assert.Equal(t, tc.expected, err)
+			})
+		}
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(Name("gurl"), Stream(stdout, io.Discard), Command("get"))
+		ctx, err := cli.RunWith([]string{"gurl", "--help"})
+
+		assert.Nil(t, ctx)
+		assert.ErrorIs(t, err, ErrHelp)
+		assert.Contains(t, stdout.String(), "Usage:\n  gurl <command>\n")
+	})
+
+	t.Run("CommandHelp", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(Name("gurl"), Stream(stdout, io.Discard), Command("get", Argument("url")))
+		_, err := cli.RunWith([]string{"gurl", "get", "-h"})
+
+		var helpErr *HelpError
+		assert.ErrorAs(t, err, &helpErr)
+		assert.Equal(t, []string{"get"}, helpErr.Path())
+		assert.Contains(t, stdout.String(), "Usage:\n  gurl get <argument>\n")
+	})
+}
+
+func TestCLIMustRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		code := -1
+		cli := New(Command("test"), ExitFunc(func(c int) { code = c }))
+		ctx := cli.MustRunWith([]string{"cli", "test"})
+
+		assert.NotNil(t, ctx)
+		assert.Equal(t, -1, code)
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		code := -1
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cli := New(Command("test"), Stream(stdout, stderr), ExitFunc(func(c int) { code = c }))
+		ctx := cli.MustRunWith([]string{"cli", "--help"})
+
+		assert.Nil(t, ctx)
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout.String(), "Usage:")
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		t.Parallel()
+
+		code := -1
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cli := New(Command("test"), Stream(stdout, stderr), ExitFunc(func(c int) { code = c }))
+		ctx := cli.MustRunWith([]string{"cli", "unknown"})
+
+		assert.Nil(t, ctx)
+		assert.Equal(t, ExitUsage, code)
+		assert.Equal(t, "unknown command: unknown\n  cli unknown\n      ^^^^^^^ expected command\n", stderr.String())
+		assert.Contains(t, stdout.String(), "Usage:")
+	})
+
+	t.Run("InvalidExitFunc", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "Invalid type for ExitFunc option", func() {
+			New(&options.ExitFunc{ExitFunc: "invalid"})
+		})
+	})
+}
+
+func TestCLIConfigure(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stream", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(Name("gurl"), Command("test"))
+		cli.Configure(Stream(stdout, &bytes.Buffer{}))
+		cli.PrintHelp()
+
+		assert.Equal(t, "gurl", cli.Name())
+		assert.Contains(t, stdout.String(), "Usage:")
+	})
+
+	t.Run("Aliases", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Aliases("a"), Command("test"))
+
+		assert.NotPanics(t, func() { cli.Configure(Aliases("b")) })
+		assert.Equal(t, "b", *cli.aliasFile)
+	})
+
+	t.Run("Getenv", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Getenv(func(key string) string { return map[string]string{"COLUMNS": "42"}[key] }))
+
+		assert.Equal(t, 42, cli.terminalWidth())
+		assert.PanicsWithValue(t, "Invalid type for Getenv option", func() {
+			New(&options.Getenv{Getenv: "invalid"})
+		})
 	})
 }
@@ -0,0 +1,166 @@
+// Package clitest runs a *cli.CLI in-process in tests. It captures the
+// output, feeds stdin, stubs the environment and records the exit code
+// instead of exiting, so help and error paths can be tested like any
+// other:
+//
+//	result := clitest.RunLine(t, newCLI(), "get --help")
+//	result.AssertExitCode(t, cli.ExitOK)
+//	result.AssertStdoutContains(t, "Usage:")
+package clitest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli"
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Result is the outcome of a run.
+type Result struct {
+	// Context is the Context returned by RunWith, nil if it failed.
+	Context *cli.Context
+	// Err is the error returned by RunWith.
+	Err    error
+	Stdout string
+	Stderr string
+	// Exited reports whether the CLI exited through its ExitFunc, as
+	// MustRun does on errors and requested help. ExitCode is the code it
+	// exited with, or ExitOK if it did not exit.
+	Exited   bool
+	ExitCode int
+}
+
+// Option configures a run.
+type Option func(r *runner)
+
+type runner struct {
+	stdin string
+	env   map[string]string
+	opts  []restriction.IsCliOption
+}
+
+// Stdin feeds input to the CLI, e.g. a script for --batch -. Prompts
+// and confirmations only read it with Interactive.
+func Stdin(input string) Option {
+	return func(r *runner) {
+		r.stdin = input
+	}
+}
+
+// Interactive asks for prompts and confirmations although stdin is not a
+// terminal, so Stdin can answer them.
+func Interactive() Option {
+	return func(r *runner) {
+		r.opts = append(r.opts, cli.Interactive())
+	}
+}
+
+// Env sets environment variables seen by the CLI. Only variables set with
+// Env are visible, the environment of the test is not.
+func Env(vars map[string]string) Option {
+	return func(r *runner) {
+		for key, value := range vars {
+			r.env[key] = value
+		}
+	}
+}
+
+// With applies further options to the CLI before it runs, e.g.
+// cli.Aliases with a file written by File.
+func With(opts ...restriction.IsCliOption) Option {
+	return func(r *runner) {
+		r.opts = append(r.opts, opts...)
+	}
+}
+
+// File writes content to a file named name in a temporary directory that
+// is removed after the test and returns its path, e.g. to stub a config
+// file.
+func File(t testing.TB, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// Run runs c with args, which do not include the program name, like
+// MustRunWith does. The streams, stdin, ExitFunc and environment of c are
+// replaced, so c should not be used outside of the test afterwards.
+func Run(t testing.TB, c *cli.CLI, args []string, opts ...Option) *Result {
+	t.Helper()
+
+	r := &runner{env: map[string]string{}}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	result := &Result{ExitCode: cli.ExitOK}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	c.Configure(append([]restriction.IsCliOption{
+		cli.Stream(stdout, stderr),
+		cli.Stdin(strings.NewReader(r.stdin)),
+		cli.Getenv(func(key string) string { return r.env[key] }),
+		cli.ExitFunc(func(code int) {
+			if !result.Exited {
+				result.Exited = true
+				result.ExitCode = code
+			}
+		}),
+	}, r.opts...)...)
+
+	argv := append([]string{c.Name()}, args...)
+	result.Context, result.Err = c.RunWith(argv)
+	c.ExitOnError(argv, result.Err)
+	result.Stdout, result.Stderr = stdout.String(), stderr.String()
+
+	return result
+}
+
+// RunLine is like Run but takes the arguments as a single command line,
+// split with cli.SplitArgs.
+func RunLine(t testing.TB, c *cli.CLI, line string, opts ...Option) *Result {
+	t.Helper()
+
+	args, err := cli.SplitArgs(line)
+	require.NoError(t, err)
+
+	return Run(t, c, args, opts...)
+}
+
+// AssertExitCode asserts that the run exited with code, ExitOK also
+// matches a run that did not exit.
+func (r *Result) AssertExitCode(t testing.TB, code int) bool {
+	t.Helper()
+
+	return assert.Equal(t, code, r.ExitCode, "exit code, stderr: %s", r.Stderr)
+}
+
+// AssertStdout asserts that the output equals want.
+func (r *Result) AssertStdout(t testing.TB, want string) bool {
+	t.Helper()
+
+	return assert.Equal(t, want, r.Stdout)
+}
+
+// AssertStdoutContains asserts that the output contains want.
+func (r *Result) AssertStdoutContains(t testing.TB, want string) bool {
+	t.Helper()
+
+	return assert.Contains(t, r.Stdout, want)
+}
+
+// AssertStderrContains asserts that the error output contains want.
+func (r *Result) AssertStderrContains(t testing.TB, want string) bool {
+	t.Helper()
+
+	return assert.Contains(t, r.Stderr, want)
+}
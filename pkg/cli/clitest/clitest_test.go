@@ -0,0 +1,140 @@
+package clitest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCLI() *cli.CLI {
+	return cli.New(
+		cli.Name("gurl"),
+		cli.Command("get",
+			cli.Description("Fetch a URL"),
+			cli.Argument("url",
+				cli.Option("user"),
+				cli.Handler(func(ctx *cli.Context) error {
+					if *ctx.GetArgument("url") == "fail" {
+						return errors.New("request failed")
+					}
+					user := "anonymous"
+					if value := ctx.GetOption("user"); value != nil {
+						user = *value
+					}
+					_, err := ctx.Stdout().Write([]byte("hello " + user + "\n"))
+					return err
+				}),
+			),
+		),
+	)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Success", func(t *testing.T) {
+		t.Parallel()
+
+		result := Run(t, newCLI(), []string{"get", "http://x", "--user", "ada"})
+
+		require.NoError(t, result.Err)
+		assert.False(t, result.Exited)
+		assert.Equal(t, "http://x", *result.Context.GetArgument("url"))
+		result.AssertExitCode(t, cli.ExitOK)
+		result.AssertStdout(t, "hello ada\n")
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		result := RunLine(t, newCLI(), "--help")
+
+		assert.ErrorIs(t, result.Err, cli.ErrHelp)
+		assert.True(t, result.Exited)
+		result.AssertExitCode(t, cli.ExitOK)
+		result.AssertStdoutContains(t, "Fetch a URL")
+	})
+
+	t.Run("UsageError", func(t *testing.T) {
+		t.Parallel()
+
+		result := RunLine(t, newCLI(), "post http://x")
+
+		assert.ErrorIs(t, result.Err, cli.UnknownCommandError("post"))
+		result.AssertExitCode(t, cli.ExitUsage)
+		result.AssertStderrContains(t, "gurl post http://x")
+	})
+
+	t.Run("HandlerError", func(t *testing.T) {
+		t.Parallel()
+
+		result := RunLine(t, newCLI(), "get fail --user ada")
+
+		result.AssertExitCode(t, cli.ExitFailure)
+		result.AssertStderrContains(t, "request failed")
+	})
+
+	t.Run("Stdin", func(t *testing.T) {
+		t.Parallel()
+
+		c := newCLI()
+		c.Configure(cli.Batch())
+
+		result := RunLine(t, c, "--batch -", Stdin("get http://x\nget http://y --user grace\n"))
+
+		require.NoError(t, result.Err)
+		result.AssertStdout(t, "hello anonymous\nhello grace\n")
+	})
+
+	t.Run("Interactive", func(t *testing.T) {
+		t.Parallel()
+
+		newDeleteCLI := func() *cli.CLI {
+			return cli.New(cli.Command("delete",
+				cli.Confirm("Delete it?"),
+				cli.Option("reason", cli.Prompt()),
+				cli.Handler(func(ctx *cli.Context) error {
+					_, err := ctx.Stdout().Write([]byte("deleted: " + *ctx.GetOption("reason") + "\n"))
+					return err
+				}),
+			))
+		}
+
+		result := RunLine(t, newDeleteCLI(), "delete", Interactive(), Stdin("cleanup\ny\n"))
+		require.NoError(t, result.Err)
+		result.AssertStdout(t, "deleted: cleanup\n")
+
+		result = RunLine(t, newDeleteCLI(), "delete", Interactive(), Stdin("cleanup\nn\n"))
+		assert.ErrorIs(t, result.Err, cli.ErrNotConfirmed)
+
+		result = RunLine(t, newDeleteCLI(), "delete --reason cleanup", Stdin("y\n"))
+		assert.ErrorIs(t, result.Err, cli.ErrConfirmationRequired)
+	})
+
+	t.Run("Env", func(t *testing.T) {
+		t.Parallel()
+
+		c := newCLI()
+		c.Configure(cli.Messages("de", cli.MapCatalog{cli.MessageUsage: "Verwendung:"}))
+
+		result := RunLine(t, c, "--help", Env(map[string]string{"LANG": "de_DE.UTF-8"}))
+		result.AssertStdoutContains(t, "Verwendung:")
+
+		result = RunLine(t, c, "--help")
+		result.AssertStdoutContains(t, "Usage:")
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+
+		aliases := File(t, "config/aliases", "g = \"get $1 --user ada\"\n")
+
+		result := RunLine(t, newCLI(), "g http://x", With(cli.Aliases(aliases)))
+
+		require.NoError(t, result.Err)
+		result.AssertStdout(t, "hello ada\n")
+	})
+}
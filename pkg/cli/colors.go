@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"io"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/term"
+)
+
+// Style is a set of ANSI SGR parameters, e.g. "1;36" for bold cyan.
+// The zero value leaves text unstyled.
+type Style string
+
+// Apply wraps text in the escape sequences of the style.
+func (s Style) Apply(text string) string {
+	if s == "" || text == "" {
+		return text
+	}
+
+	return "\x1b[" + string(s) + "m" + text + "\x1b[0m"
+}
+
+// Theme defines the styles used for help and error output.
+// The zero value disables all styling.
+type Theme struct {
+	Heading     Style
+	Command     Style
+	Option      Style
+	Placeholder Style
+	Error       Style
+}
+
+// DefaultTheme is a theme that works on dark and light terminals.
+var DefaultTheme = Theme{
+	Heading:     "1",
+	Command:     "36",
+	Option:      "33",
+	Placeholder: "2",
+	Error:       "1;31",
+}
+
+// Values of the --color flag that is available if Colors is configured.
+const (
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
+)
+
+var colorModes = []string{ColorAuto, ColorAlways, ColorNever}
+
+// themeFor returns the theme to use for output written to w in the color
+// mode of the --color flag. Styling is enabled if a theme is configured
+// and the mode is ColorAlways, or if w is a terminal, NO_COLOR is not set
+// and the mode is not ColorNever.
+func (c *CLI) themeFor(w io.Writer, color string) Theme {
+	if c.theme == nil {
+		return Theme{}
+	}
+
+	switch color {
+	case ColorAlways:
+		return *c.theme
+	case ColorNever:
+		return Theme{}
+	}
+
+	if c.getenv("NO_COLOR") != "" || !term.IsTerminal(w) {
+		return Theme{}
+	}
+
+	return *c.theme
+}
+
+// parseColorFlag removes the --color flag from args and returns its value,
+// fallback if it is not given. A --color without a mode following it means
+// always.
+func (c *CLI) parseColorFlag(args *flagArgs, fallback string) (string, error) {
+	value, index, found := args.extractFlag("color", colorModes...)
+	if !found {
+		return fallback, nil
+	} else if value == "" {
+		return ColorAlways, nil
+	}
+
+	switch value {
+	case ColorAuto, ColorAlways, ColorNever:
+	default:
+		return "", &ParseError{
+			Token:    value,
+			Index:    index,
+			Expected: "auto, always or never",
+			Err:      &InvalidValueError{on: "color", value: value},
+		}
+	}
+
+	return value, nil
+}
+
+// colorMode returns the color mode given with --color in argv, fallback
+// if it is missing or invalid. It is used to report errors after a run.
+func (c *CLI) colorMode(argv []string, fallback string) string {
+	if c.theme == nil || len(argv) == 0 {
+		return fallback
+	}
+	if color, err := c.parseColorFlag(newFlagArgs(c.node(), argv), fallback); err == nil {
+		return color
+	}
+
+	return fallback
+}
+
+// printError writes the translated message of err to stderr, styled with
+// the error style of the theme in the given color mode.
+func (c *CLI) printError(err error, color string) {
+	message := c.translator().errorMessage(err)
+	io.WriteString(c.stderr, c.themeFor(c.stderr, color).Error.Apply(message)+"\n")
+}
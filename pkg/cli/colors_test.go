@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStyle(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "\x1b[1;31mtext\x1b[0m", Style("1;31").Apply("text"))
+	assert.Equal(t, "text", Style("").Apply("text"))
+	assert.Equal(t, "", Style("1").Apply(""))
+}
+
+func TestColors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Option", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Colors(DefaultTheme))
+		assert.Equal(t, DefaultTheme, *cli.theme)
+
+		assert.PanicsWithValue(t, "Invalid type for Colors option", func() {
+			New(&options.Colors{Theme: "invalid"})
+		})
+	})
+
+	t.Run("NoTheme", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New()
+		assert.Equal(t, Theme{}, cli.themeFor(&bytes.Buffer{}, ColorAlways))
+	})
+
+	t.Run("Modes", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Colors(DefaultTheme))
+		for mode, expected := range map[string]Theme{
+			ColorAuto:   {},
+			ColorAlways: DefaultTheme,
+			ColorNever:  {},
+		} {
+			assert.Equal(t, expected, cli.themeFor(&bytes.Buffer{}, mode), mode)
+		}
+	})
+
+	t.Run("ColorFlag", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Colors(DefaultTheme), Option("opt"))
+		argv := []string{"cli", "--color=always", "--opt", "value"}
+		ctx, err := cli.RunWith(argv)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"opt": "value"}, ctx.options)
+		assert.Equal(t, ColorAlways, cli.colorMode(argv, ColorAuto))
+		assert.Equal(t, ColorNever, cli.colorMode([]string{"cli", "--color=rainbow"}, ColorNever))
+	})
+
+	t.Run("InvalidColorFlag", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Colors(DefaultTheme))
+		_, err := cli.RunWith([]string{"cli", "--color=rainbow"})
+
+		assert.Equal(t, &ParseError{
+			Token:    "rainbow",
+			Index:    1,
+			Expected: "auto, always or never",
+			Err:      &InvalidValueError{on: "color", value: "rainbow"},
+		}, err)
+	})
+
+	t.Run("ColorFlagWithoutMode", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Colors(DefaultTheme), Command("get", Handler(func(ctx *Context) error { return nil })))
+		ctx, err := cli.RunWith([]string{"cli", "--color", "get"})
+
+		require.NoError(t, err)
+		assert.True(t, ctx.VisitedCommand("get"))
+		assert.Equal(t, ColorAlways, cli.colorMode([]string{"cli", "--color", "get"}, ColorNever))
+		assert.Equal(t, ColorAlways, cli.colorMode([]string{"cli", "get", "--color"}, ColorNever))
+	})
+
+	t.Run("ColorFlagShadowedByCommandOption", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(
+			Colors(DefaultTheme),
+			Command("paint", Option("color"), Handler(func(ctx *Context) error { return nil })),
+		)
+		argv := []string{"cli", "--color", "never", "paint", "--color", "red"}
+		ctx, err := cli.RunWith(argv)
+
+		require.NoError(t, err)
+		assert.Equal(t, "red", *ctx.GetOption("color"))
+		assert.Equal(t, ColorNever, cli.colorMode(argv, ColorAuto))
+	})
+
+	t.Run("ColorFlagWithoutTheme", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Option("color"))
+		ctx, err := cli.RunWith([]string{"cli", "--color", "rainbow"})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"color": "rainbow"}, ctx.options)
+	})
+
+	t.Run("PrintError", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := New(Colors(DefaultTheme), Stream(&bytes.Buffer{}, stderr))
+		cli.printError(errors.New("failed"), ColorAlways)
+
+		assert.Equal(t, "\x1b[1;31mfailed\x1b[0m\n", stderr.String())
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, DefaultHelpRenderer{}.Render(buf, &HelpData{
+			Name:     "gurl",
+			Commands: []HelpCommand{{Name: "get"}},
+			Groups: []HelpGroup{
+				{Title: "Commands", Style: DefaultTheme.Command, Entries: []HelpEntry{{Name: "get", Description: "Get"}}},
+			},
+			Theme: DefaultTheme,
+		}))
+
+		assert.Contains(t, buf.String(), "\x1b[1mUsage:\x1b[0m\n  gurl \x1b[2m<command>\x1b[0m\n")
+		assert.Contains(t, buf.String(), "\x1b[1mCommands:\x1b[0m\n  \x1b[36mget\x1b[0m  Get\n")
+	})
+}
@@ -15,6 +15,7 @@ type command struct {
 	example     *string
 	description *string
 	handler     *HandlerFunc
+	hooks       hooks
 	command     []*command
 	argument    *argument
 	options     []*option
@@ -38,6 +39,14 @@ func Command(name string, opts ...restriction.IsCommandOption) *command {
 			} else {
 				panic("Invalid type for Handler option")
 			}
+		case *options.PreRun:
+			o.hooks.setPreRun(v)
+		case *options.PostRun:
+			o.hooks.setPostRun(v)
+		case *options.Middleware:
+			o.hooks.use(v)
+		case *options.Confirm:
+			o.hooks.confirm = &v.Message
 		case *command:
 			if o.argument != nil {
 				panic(MixOfArgumentAndCommandError(v.name))
@@ -60,6 +69,10 @@ func Command(name string, opts ...restriction.IsCommandOption) *command {
 		}
 	}
 
+	if o.hooks.confirm != nil {
+		o.addYesOption()
+	}
+
 	return o
 }
 
@@ -79,6 +92,8 @@ func (c *command) call(args *utils.AdvancedArray[string], ctx *Context) error {
 		}
 
 		ctx.commands = append(ctx.commands, c.name)
+		ctx.path = append(ctx.path, c.name)
+		ctx.lineage = append(ctx.lineage, &c.hooks)
 
 		if c.argument != nil {
 			if err := c.argument.call(args, ctx); err != nil {
@@ -106,28 +121,24 @@ func (c *command) call(args *utils.AdvancedArray[string], ctx *Context) error {
 
 			arg, _ := args.Next()
 
-			return UnknownCommandError(arg)
+			return newParseError(ctx, arg, args.Index()-1, "command", UnknownCommandError(arg))
 		}
 
-		for _, opt := range c.options {
-			if err := opt.call(args, ctx); err != nil && err != ErrNotMatched {
-				if helpErr, ok := err.(*HelpError); ok {
-					helpErr.on = c
-					return helpErr
-				}
-
-				return err
+		if err := callOptions(c.options, args, ctx); err != nil {
+			if helpErr, ok := err.(*HelpError); ok {
+				helpErr.on = c
+				return helpErr
 			}
+
+			return err
 		}
 
-		if c.handler != nil {
-			if err := (*c.handler)(ctx); err != nil {
-				return err
-			}
+		if err := ctx.promptOptions(c.options, args.Index()); err != nil {
+			return err
 		}
 
-		return nil
+		return ctx.execute(&c.hooks, c.handler)
 	}
 
-	return ErrUnexpectedEndCommand
+	return newParseError(ctx, "", args.Index(), "command", ErrUnexpectedEndCommand)
 }
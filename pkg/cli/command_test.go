@@ -184,7 +184,7 @@ func TestCommandCall(t *testing.T) {
 		err := cmd.call(args, ctx)
 
 		assert.Error(t, err)
-		assert.Equal(t, UnknownCommandError("sub"), err)
+		assert.Equal(t, UnknownCommandError("sub"), parseErrorCause(t, err))
 	})
 
 	t.Run("MatchWithSubcommandAndUnexpectedEnd", func(t *testing.T) {
@@ -198,7 +198,7 @@ func TestCommandCall(t *testing.T) {
 		err := cmd.call(args, ctx)
 
 		assert.Error(t, err)
-		assert.Equal(t, ErrUnexpectedEndCommand, err)
+		assert.Equal(t, ErrUnexpectedEndCommand, parseErrorCause(t, err))
 	})
 
 	t.Run("WithOptions", func(t *testing.T) {
@@ -270,7 +270,7 @@ func TestCommandCall(t *testing.T) {
 		assert.Equal(t, &InvalidValueError{
 			on:    "opt",
 			value: "value",
-		}, err)
+		}, parseErrorCause(t, err))
 	})
 
 	t.Run("SubCommandWithHelpOption", func(t *testing.T) {
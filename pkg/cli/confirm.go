@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+)
+
+// yesOption is the name of the option added below commands with Confirm.
+const yesOption = "yes"
+
+// addYesOption adds the --yes, -y option to the leaves from c down.
+func (c *command) addYesOption() {
+	if c.argument == nil && len(c.command) == 0 {
+		c.options = withYesOption(c.options)
+	}
+	if c.argument != nil {
+		c.argument.addYesOption()
+	}
+	for _, cmd := range c.command {
+		cmd.addYesOption()
+	}
+}
+
+func (a *argument) addYesOption() {
+	if a.argument == nil && len(a.command) == 0 {
+		a.options = withYesOption(a.options)
+	}
+	if a.argument != nil {
+		a.argument.addYesOption()
+	}
+	for _, cmd := range a.command {
+		cmd.addYesOption()
+	}
+}
+
+// withYesOption adds the --yes option to opts unless there already is an
+// option of that name. It only gets the short flag -y if that is free.
+func withYesOption(opts []*option) []*option {
+	short := true
+	for _, opt := range opts {
+		if opt.long == yesOption {
+			return opts
+		}
+		if opt.short != nil && *opt.short == 'y' {
+			short = false
+		}
+	}
+
+	yesOpts := []restriction.IsOptionOption{Description("Skip the confirmation prompt")}
+	if short {
+		yesOpts = append(yesOpts, Short('y'))
+	}
+
+	return append(opts, Option(yesOption, yesOpts...))
+}
+
+// confirm asks for the confirmations of the matched commands, unless
+// --yes was given. The message of a command is formatted with the values
+// of the arguments matched below it.
+func (ctx *Context) confirm() error {
+	for i, h := range ctx.lineage {
+		if h.confirm == nil || ctx.UsedOption(yesOption) {
+			continue
+		}
+		if ctx.prompter == nil {
+			return ErrConfirmationRequired
+		}
+
+		values := []interface{}{}
+		for _, name := range ctx.path[i:] {
+			if value, ok := ctx.arguments[name]; ok {
+				values = append(values, value)
+			}
+		}
+
+		if !ctx.prompter.confirm(*h.confirm, values) {
+			return ErrNotConfirmed
+		}
+	}
+
+	return nil
+}
+
+// confirm asks the yes/no question message, formatted with values. Only
+// an answer in MessageConfirmYes counts as yes.
+func (p *prompter) confirm(message string, values []interface{}) bool {
+	message = p.translator.text(message)
+	if verbs := strings.Count(message, "%") - 2*strings.Count(message, "%%"); verbs > 0 {
+		message = fmt.Sprintf(message, values[:min(verbs, len(values))]...)
+	}
+	io.WriteString(p.out, p.translator.message(MessageConfirm, message))
+
+	line, _ := p.readLine(false)
+	answer := strings.ToLower(strings.TrimSpace(line))
+	for _, yes := range strings.Split(p.translator.message(MessageConfirmYes), ",") {
+		if answer != "" && answer == strings.TrimSpace(yes) {
+			return true
+		}
+	}
+
+	return false
+}
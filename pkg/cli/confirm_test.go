@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirm(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Yes", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		deleted := ""
+		cli := newTestCLI("y\n", true,
+			Stream(io.Discard, stderr),
+			Command("delete", Confirm("This will delete %s. Continue?"), Argument("name",
+				Option("force"),
+				Handler(func(ctx *Context) error {
+					deleted = *ctx.GetArgument("name")
+					return nil
+				}),
+			)),
+		)
+		_, err := cli.RunWith([]string{"cli", "delete", "prod"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "prod", deleted)
+		assert.Equal(t, "This will delete prod. Continue? [y/N]: ", stderr.String())
+	})
+
+	t.Run("No", func(t *testing.T) {
+		t.Parallel()
+
+		deleted := ""
+		cli := newTestCLI("\n", true,
+			Stream(io.Discard, io.Discard),
+			Command("delete", Confirm("This will delete %s. Continue?"), Argument("name",
+				Option("force"),
+				Handler(func(ctx *Context) error {
+					deleted = *ctx.GetArgument("name")
+					return nil
+				}),
+			)),
+		)
+		_, err := cli.RunWith([]string{"cli", "delete", "prod"})
+
+		assert.ErrorIs(t, err, ErrNotConfirmed)
+		assert.Empty(t, deleted)
+	})
+
+	t.Run("YesFlag", func(t *testing.T) {
+		t.Parallel()
+
+		for _, flag := range []string{"--yes", "-y"} {
+			stderr := &bytes.Buffer{}
+			deleted := ""
+			cli := newTestCLI("", false,
+				Stream(io.Discard, stderr),
+				Command("delete", Confirm("This will delete %s. Continue?"), Argument("name",
+					Option("force"),
+					Handler(func(ctx *Context) error {
+						deleted = *ctx.GetArgument("name")
+						return nil
+					}),
+				)),
+			)
+			_, err := cli.RunWith([]string{"cli", "delete", "prod", "--force", flag})
+
+			require.NoError(t, err)
+			assert.Equal(t, "prod", deleted)
+			assert.Empty(t, stderr.String())
+		}
+	})
+
+	t.Run("YesFlagBeforeOption", func(t *testing.T) {
+		t.Parallel()
+
+		deleted := ""
+		cli := newTestCLI("", false,
+			Stream(io.Discard, io.Discard),
+			Command("delete", Confirm("This will delete %s. Continue?"), Argument("name",
+				Option("force"),
+				Handler(func(ctx *Context) error {
+					deleted = *ctx.GetArgument("name")
+					return nil
+				}),
+			)),
+		)
+		ctx, err := cli.RunWith([]string{"cli", "delete", "prod", "--yes", "--force"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "prod", deleted)
+		assert.True(t, ctx.UsedOption("force"))
+		assert.True(t, ctx.UsedOption("yes"))
+	})
+
+	t.Run("NonInteractive", func(t *testing.T) {
+		t.Parallel()
+
+		deleted := ""
+		cli := newTestCLI("y\n", false,
+			Stream(io.Discard, io.Discard),
+			Command("delete", Confirm("This will delete %s. Continue?"), Argument("name",
+				Option("force"),
+				Handler(func(ctx *Context) error {
+					deleted = *ctx.GetArgument("name")
+					return nil
+				}),
+			)),
+		)
+		_, err := cli.RunWith([]string{"cli", "delete", "prod"})
+
+		assert.ErrorIs(t, err, ErrConfirmationRequired)
+		assert.Equal(t, ExitUsage, cli.exitCode(err))
+		assert.Empty(t, deleted)
+
+		cli = newTestCLI("y\n", true,
+			Stream(io.Discard, io.Discard),
+			Command("delete", Confirm("This will delete %s. Continue?"), Argument("name",
+				Option("force"),
+				Handler(func(ctx *Context) error {
+					deleted = *ctx.GetArgument("name")
+					return nil
+				}),
+			)),
+		)
+		_, err = cli.RunWith([]string{"cli", "--no-input", "delete", "prod"})
+		assert.ErrorIs(t, err, ErrConfirmationRequired)
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(
+			Stream(stdout, &bytes.Buffer{}),
+			Command("purge", Confirm("Purge everything?")),
+		)
+		_, err := cli.RunWith([]string{"cli", "purge", "--help"})
+
+		assert.ErrorIs(t, err, ErrHelp)
+		assert.Contains(t, stdout.String(), "--yes, -y")
+	})
+	t.Run("ShortFlagTaken", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Command("purge",
+			Confirm("Purge everything?"),
+			Option("years", Short('y')),
+			Handler(func(ctx *Context) error { return nil }),
+		))
+		ctx, err := cli.RunWith([]string{"cli", "purge", "-y", "3", "--yes"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "3", *ctx.GetOption("years"))
+		assert.True(t, ctx.UsedOption(yesOption))
+
+		yes := cli.command[0].options[1]
+		assert.Equal(t, yesOption, yes.long)
+		assert.Nil(t, yes.short)
+	})
+}
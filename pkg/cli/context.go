@@ -1,9 +1,33 @@
 package cli
 
+import (
+	"context"
+	"io"
+	"os"
+)
+
 type Context struct {
 	commands  []string
 	arguments map[string]string
 	options   map[string]string
+	// path holds the names of the matched commands and arguments in order.
+	path []string
+	// lineage holds the hooks of the CLI and the matched commands and
+	// arguments, from the root to the leaf.
+	lineage []*hooks
+	// context is cancelled when the CLI is interrupted.
+	context context.Context
+	// values holds the values injected with Inject or set with Set.
+	values map[interface{}]interface{}
+	// stdout and output are where and how Output writes.
+	stdout io.Writer
+	output outputFormat
+	// prompter asks for missing values, it is nil if prompting is disabled.
+	prompter *prompter
+	// stdin is read by --<name>-file - of secret options.
+	stdin io.Reader
+	// secrets holds the names of the given secret options.
+	secrets map[string]bool
 }
 
 func NewContext() *Context {
@@ -11,7 +35,48 @@ func NewContext() *Context {
 		commands:  make([]string, 0),
 		arguments: make(map[string]string),
 		options:   make(map[string]string),
+		values:    make(map[interface{}]interface{}),
+		secrets:   make(map[string]bool),
+	}
+}
+
+// Context returns the context.Context of the run. It is cancelled on the
+// first SIGINT or SIGTERM, so long running handlers can shut down cleanly.
+func (c *Context) Context() context.Context {
+	if c.context == nil {
+		return context.Background()
+	}
+
+	return c.context
+}
+
+// Stdout returns the stream the handler should write its output to, see
+// Stream. It is os.Stdout unless configured otherwise.
+func (c *Context) Stdout() io.Writer {
+	if c.stdout == nil {
+		return os.Stdout
 	}
+
+	return c.stdout
+}
+
+// Set stores value under key, e.g. to pass a client from a PreRun hook
+// or middleware to the handler. Like with context.WithValue, key should be
+// of an unexported type to avoid collisions.
+func (c *Context) Set(key, value interface{}) {
+	c.values[key] = value
+}
+
+// Value returns the value stored under key with Set or Inject, or nil.
+func (c *Context) Value(key interface{}) interface{} {
+	return c.values[key]
+}
+
+// ValueOf returns the value stored under key if it is of type T.
+func ValueOf[T any](ctx *Context, key interface{}) (T, bool) {
+	value, ok := ctx.Value(key).(T)
+
+	return value, ok
 }
 
 func (c *Context) VisitedCommand(command string) bool {
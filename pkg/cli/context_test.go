@@ -1,6 +1,9 @@
 package cli
 
 import (
+	"bytes"
+	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -77,3 +80,60 @@ func TestContext_GetOption(t *testing.T) {
 	nonexistent := ctx.GetOption("nonexistent")
 	assert.Nil(t, nonexistent)
 }
+
+func TestContext_Value(t *testing.T) {
+	t.Parallel()
+
+	type key struct{}
+	ctx := NewContext()
+	ctx.Set(key{}, 42)
+
+	assert.Equal(t, 42, ctx.Value(key{}))
+	assert.Nil(t, ctx.Value("missing"))
+
+	value, ok := ValueOf[int](ctx, key{})
+	assert.True(t, ok)
+	assert.Equal(t, 42, value)
+
+	_, ok = ValueOf[string](ctx, key{})
+	assert.False(t, ok)
+}
+
+func TestContext_Stdout(t *testing.T) {
+	t.Parallel()
+
+	stdout := &bytes.Buffer{}
+	cli := New(Stream(stdout, &bytes.Buffer{}), Handler(func(ctx *Context) error {
+		_, err := io.WriteString(ctx.Stdout(), "out")
+		return err
+	}))
+	_, err := cli.RunWith([]string{"cli"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "out", stdout.String())
+	assert.Equal(t, os.Stdout, NewContext().Stdout())
+}
+
+func TestContext_Inject(t *testing.T) {
+	t.Parallel()
+
+	type clientKey struct{}
+	type userKey struct{}
+	var user string
+	cli := New(
+		Inject(clientKey{}, "client"),
+		PersistentPreRun(func(ctx *Context) error {
+			client, _ := ValueOf[string](ctx, clientKey{})
+			ctx.Set(userKey{}, "user of "+client)
+			return nil
+		}),
+		Command("whoami", Handler(func(ctx *Context) error {
+			user, _ = ValueOf[string](ctx, userKey{})
+			return nil
+		})),
+	)
+	_, err := cli.RunWith([]string{"cli", "whoami"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "user of client", user)
+}
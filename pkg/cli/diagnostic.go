@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// diagnostic renders the command line with a caret marker below the token
+// a ParseError points at, with the values of secret options redacted, e.g.
+//
+//	gurl get htp://x
+//	         ^^^^^^^ expected value for <url>
+func (c *CLI) diagnostic(argv []string, err *ParseError, t translator) string {
+	line := c.Name()
+	offset, length := -1, 1
+	secrets := secretFlags(c.node())
+
+	for i, arg := range argv {
+		if i == 0 {
+			continue
+		}
+
+		flag, _, assigned := strings.Cut(arg, "=")
+		if slices.Contains(err.secrets, arg) || secrets[argv[i-1]] {
+			arg = redacted
+		} else if assigned && secrets[flag] {
+			arg = flag + "=" + redacted
+		}
+		arg = displayArg(arg)
+		if i == err.Index {
+			offset = utf8.RuneCountInString(line) + 1
+			length = max(utf8.RuneCountInString(arg), 1)
+		}
+		line += " " + arg
+	}
+
+	if offset < 0 {
+		offset = utf8.RuneCountInString(line) + 1
+	}
+
+	marker := strings.Repeat(" ", offset) + strings.Repeat("^", length)
+	if err.Expected != "" {
+		marker += " " + t.message(MessageExpected, err.Expected)
+	}
+
+	return "  " + line + "\n  " + marker
+}
+
+// displayArg quotes arg if it would be ambiguous on a command line.
+func displayArg(arg string) string {
+	if arg == "" || strings.ContainsAny(arg, " \t\"'") {
+		return strconv.Quote(arg)
+	}
+
+	return arg
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnostic(t *testing.T) {
+	t.Parallel()
+
+	cli := New(Name("gurl"))
+
+	t.Run("Token", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostic := cli.diagnostic(
+			[]string{"./gurl", "get", "htp://x"},
+			&ParseError{Token: "htp://x", Index: 2, Expected: "value for <url>"},
+			translator{},
+		)
+
+		assert.Equal(t, "  gurl get htp://x\n           ^^^^^^^ expected value for <url>", diagnostic)
+	})
+
+	t.Run("QuotedToken", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostic := cli.diagnostic([]string{"gurl", "a b", "c"}, &ParseError{Token: "c", Index: 2}, translator{})
+
+		assert.Equal(t, "  gurl \"a b\" c\n             ^", diagnostic)
+	})
+
+	t.Run("UnexpectedEnd", func(t *testing.T) {
+		t.Parallel()
+
+		diagnostic := cli.diagnostic([]string{"gurl", "get"}, &ParseError{Index: 2, Expected: "argument <url>"}, translator{})
+
+		assert.Equal(t, "  gurl get\n           ^ expected argument <url>", diagnostic)
+	})
+}
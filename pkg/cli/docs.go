@@ -0,0 +1,445 @@
+package cli
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// docPage describes one generated documentation page. Every command gets its
+// own page, arguments are folded into the page of the command they belong to.
+type docPage struct {
+	path        []string
+	description *string
+	examples    []string
+	arguments   []*argument
+	options     []*option
+	parent      *docPage
+	children    []*docPage
+}
+
+func newDocPage(parent *docPage, name string, n node) *docPage {
+	p := &docPage{
+		parent:      parent,
+		description: n.description,
+	}
+
+	if parent != nil {
+		p.path = append(p.path, parent.path...)
+	}
+	p.path = append(p.path, name)
+
+	if n.example != nil {
+		p.examples = append(p.examples, *n.example)
+	}
+
+	for n.argument != nil {
+		p.arguments = append(p.arguments, n.argument)
+		n = n.argument.node()
+		if n.example != nil {
+			p.examples = append(p.examples, *n.example)
+		}
+	}
+
+	p.options = n.options
+	for _, cmd := range n.command {
+		p.children = append(p.children, newDocPage(p, cmd.name, cmd.node()))
+	}
+
+	return p
+}
+
+func (p *docPage) title() string {
+	return strings.Join(p.path, " ")
+}
+
+func (p *docPage) file(ext string) string {
+	return strings.Join(p.path, "_") + ext
+}
+
+func (p *docPage) usage() string {
+	sb := strings.Builder{}
+	sb.WriteString(p.title())
+	for _, arg := range p.arguments {
+		sb.WriteString(" <" + arg.name + ">")
+	}
+	if len(p.children) > 0 {
+		sb.WriteString(" <command>")
+	}
+	if len(p.options) > 0 {
+		sb.WriteString(" [options...]")
+	}
+
+	return sb.String()
+}
+
+// walk calls fn for the page and all of its descendants in depth-first order.
+func (p *docPage) walk(depth int, fn func(p *docPage, depth int)) {
+	fn(p, depth)
+	for _, child := range p.children {
+		child.walk(depth+1, fn)
+	}
+}
+
+// graph returns a mermaid flowchart of the commands and arguments below the page.
+func (p *docPage) graph() string {
+	sb := strings.Builder{}
+	sb.WriteString("flowchart LR\n")
+
+	id := 0
+	var write func(p *docPage, label string) string
+	write = func(p *docPage, label string) string {
+		self := fmt.Sprintf("n%d", id)
+		id++
+		sb.WriteString(fmt.Sprintf("%s[%q]\n", self, label))
+
+		last := self
+		for _, arg := range p.arguments {
+			argID := fmt.Sprintf("n%d", id)
+			id++
+			sb.WriteString(fmt.Sprintf("%s --> %s([%q])\n", last, argID, arg.name))
+			last = argID
+		}
+
+		for _, child := range p.children {
+			childID := write(child, child.path[len(child.path)-1])
+			sb.WriteString(last + " --> " + childID + "\n")
+		}
+
+		return self
+	}
+	write(p, p.title())
+
+	return sb.String()
+}
+
+// GenerateMarkdown writes a Markdown reference page for the CLI and every
+// command into dir, together with an index.md that links all pages and
+// contains a mermaid graph of the command tree. Nothing is written if two
+// pages, or a page and the index, would have the same file name.
+func (c *CLI) GenerateMarkdown(dir string) error {
+	root := newDocPage(nil, c.Name(), c.node())
+
+	return writeDocs(dir, root, ".md", markdownPage, markdownIndex)
+}
+
+// GenerateHTML works like GenerateMarkdown but writes HTML pages.
+func (c *CLI) GenerateHTML(dir string) error {
+	root := newDocPage(nil, c.Name(), c.node())
+
+	return writeDocs(dir, root, ".html", htmlPage, htmlIndex)
+}
+
+func writeDocs(
+	dir string, root *docPage, ext string,
+	page func(p *docPage, ext string) (string, error),
+	index func(root *docPage, ext string) (string, error),
+) error {
+	// Pages must not overwrite each other or the index, e.g. for a CLI
+	// named "index" or the commands "a b" and "a_b".
+	files := map[string]string{"index" + ext: "the index"}
+	var err error
+	root.walk(0, func(p *docPage, _ int) {
+		if other, ok := files[p.file(ext)]; ok && err == nil {
+			err = fmt.Errorf("the page of %q would overwrite %s in %s", p.title(), other, p.file(ext))
+		}
+		files[p.file(ext)] = fmt.Sprintf("the page of %q", p.title())
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	root.walk(0, func(p *docPage, _ int) {
+		if err != nil {
+			return
+		}
+
+		var content string
+		if content, err = page(p, ext); err == nil {
+			err = os.WriteFile(filepath.Join(dir, p.file(ext)), []byte(content), 0o644)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	content, err := index(root, ext)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "index"+ext), []byte(content), 0o644)
+}
+
+func markdownEscape(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func markdownPage(p *docPage, ext string) (string, error) {
+	sb := strings.Builder{}
+
+	sb.WriteString("# " + p.title() + "\n\n")
+	if p.description != nil {
+		sb.WriteString(*p.description + "\n\n")
+	}
+
+	sb.WriteString("## Usage\n\n```\n" + p.usage() + "\n```\n\n")
+
+	if len(p.arguments) > 0 {
+		sb.WriteString("## Arguments\n\n")
+		sb.WriteString("| Name | Description | Pattern |\n")
+		sb.WriteString("| --- | --- | --- |\n")
+		for _, arg := range p.arguments {
+			row := docArgumentRow(arg)
+			sb.WriteString("| `<" + row.Name + ">` | " + markdownEscape(row.Description) + " | ")
+			if row.Pattern != "" {
+				sb.WriteString("`" + markdownEscape(row.Pattern) + "`")
+			}
+			sb.WriteString(" |\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(p.options) > 0 {
+		sb.WriteString("## Options\n\n")
+		sb.WriteString("| Option | Short | Description | Default | Required |\n")
+		sb.WriteString("| --- | --- | --- | --- | --- |\n")
+		for _, opt := range p.options {
+			row := docOptionRow(opt)
+			sb.WriteString("| `--" + row.Name + "` | ")
+			if row.Short != "" {
+				sb.WriteString("`-" + row.Short + "`")
+			}
+			sb.WriteString(" | " + markdownEscape(row.Description) + " | ")
+			if row.Default != "" {
+				sb.WriteString("`" + markdownEscape(row.Default) + "`")
+			}
+			sb.WriteString(" | ")
+			if row.Required {
+				sb.WriteString("yes")
+			}
+			sb.WriteString(" |\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(p.examples) > 0 {
+		sb.WriteString("## Examples\n\n```\n")
+		for _, example := range p.examples {
+			sb.WriteString(example + "\n")
+		}
+		sb.WriteString("```\n\n")
+	}
+
+	if len(p.children) > 0 {
+		sb.WriteString("## Commands\n\n")
+		for _, child := range p.children {
+			sb.WriteString("* [" + child.title() + "](" + child.file(ext) + ")")
+			if child.description != nil {
+				sb.WriteString(" - " + markdownEscape(*child.description))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## See also\n\n")
+	if p.parent != nil {
+		sb.WriteString("* [" + p.parent.title() + "](" + p.parent.file(ext) + ")\n")
+	}
+	sb.WriteString("* [Index](index" + ext + ")\n")
+
+	return sb.String(), nil
+}
+
+func markdownIndex(root *docPage, ext string) (string, error) {
+	sb := strings.Builder{}
+
+	sb.WriteString("# " + root.title() + "\n\n")
+	if root.description != nil {
+		sb.WriteString(*root.description + "\n\n")
+	}
+
+	sb.WriteString("## Command graph\n\n```mermaid\n" + root.graph() + "```\n\n")
+
+	sb.WriteString("## Pages\n\n")
+	root.walk(0, func(p *docPage, depth int) {
+		sb.WriteString(strings.Repeat("  ", depth) + "* [" + p.title() + "](" + p.file(ext) + ")\n")
+	})
+
+	return sb.String(), nil
+}
+
+type docRow struct {
+	Name        string
+	Short       string
+	Description string
+	Pattern     string
+	Default     string
+	Required    bool
+}
+
+type docLink struct {
+	Title       string
+	File        string
+	Description string
+	Depth       int
+}
+
+func docArgumentRow(arg *argument) docRow {
+	row := docRow{Name: arg.name}
+	if arg.description != nil {
+		row.Description = *arg.description
+	}
+	if arg.validate != nil {
+		row.Pattern = arg.validate.String()
+	}
+
+	return row
+}
+
+func docOptionRow(opt *option) docRow {
+	row := docRow{Name: opt.long, Required: opt.required}
+	if opt.short != nil {
+		row.Short = string(*opt.short)
+	}
+	if opt.description != nil {
+		row.Description = *opt.description
+	}
+	if opt.validate != nil {
+		row.Pattern = opt.validate.String()
+	}
+	if opt.defaultValue != nil && !opt.secret {
+		row.Default = *opt.defaultValue
+	}
+
+	return row
+}
+
+func docLinkOf(p *docPage, ext string, depth int) docLink {
+	link := docLink{Title: p.title(), File: p.file(ext), Depth: depth}
+	if p.description != nil {
+		link.Description = *p.description
+	}
+
+	return link
+}
+
+const htmlLayout = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{if .Description}}<p>{{.Description}}</p>
+{{end}}{{block "content" .}}{{end}}</body>
+</html>
+`
+
+var htmlPageTemplate = template.Must(template.Must(template.New("page").Parse(htmlLayout)).Parse(
+	`{{define "content"}}<h2>Usage</h2>
+<pre>{{.Usage}}</pre>
+{{if .Arguments}}<h2>Arguments</h2>
+<table>
+<tr><th>Name</th><th>Description</th><th>Pattern</th></tr>
+{{range .Arguments}}<tr><td><code>&lt;{{.Name}}&gt;</code></td><td>{{.Description}}</td><td>{{if .Pattern}}<code>{{.Pattern}}</code>{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Options}}<h2>Options</h2>
+<table>
+<tr><th>Option</th><th>Short</th><th>Description</th><th>Default</th><th>Required</th></tr>
+{{range .Options}}<tr><td><code>--{{.Name}}</code></td><td>{{if .Short}}<code>-{{.Short}}</code>{{end}}</td><td>{{.Description}}</td><td>{{if .Default}}<code>{{.Default}}</code>{{end}}</td><td>{{if .Required}}yes{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Examples}}<h2>Examples</h2>
+<pre>{{range .Examples}}{{.}}
+{{end}}</pre>
+{{end}}{{if .Children}}<h2>Commands</h2>
+<ul>
+{{range .Children}}<li><a href="{{.File}}">{{.Title}}</a>{{if .Description}} - {{.Description}}{{end}}</li>
+{{end}}</ul>
+{{end}}<h2>See also</h2>
+<ul>
+{{with .Parent}}<li><a href="{{.File}}">{{.Title}}</a></li>
+{{end}}<li><a href="{{.Index}}">Index</a></li>
+</ul>
+{{end}}`))
+
+var htmlIndexTemplate = template.Must(template.Must(template.New("index").Parse(htmlLayout)).Parse(
+	`{{define "content"}}<h2>Command graph</h2>
+<pre class="mermaid">
+{{.Graph}}</pre>
+<h2>Pages</h2>
+<ul>
+{{range .Pages}}<li style="margin-left: {{.Depth}}em"><a href="{{.File}}">{{.Title}}</a></li>
+{{end}}</ul>
+<script type="module">
+import mermaid from "https://cdn.jsdelivr.net/npm/mermaid@11/dist/mermaid.esm.min.mjs";
+mermaid.initialize({ startOnLoad: true });
+</script>
+{{end}}`))
+
+func htmlPage(p *docPage, ext string) (string, error) {
+	data := struct {
+		docLink
+		Usage     string
+		Arguments []docRow
+		Options   []docRow
+		Examples  []string
+		Children  []docLink
+		Parent    *docLink
+		Index     string
+	}{
+		docLink:  docLinkOf(p, ext, 0),
+		Usage:    p.usage(),
+		Examples: p.examples,
+		Index:    "index" + ext,
+	}
+
+	for _, arg := range p.arguments {
+		data.Arguments = append(data.Arguments, docArgumentRow(arg))
+	}
+	for _, opt := range p.options {
+		data.Options = append(data.Options, docOptionRow(opt))
+	}
+	for _, child := range p.children {
+		data.Children = append(data.Children, docLinkOf(child, ext, 0))
+	}
+	if p.parent != nil {
+		parent := docLinkOf(p.parent, ext, 0)
+		data.Parent = &parent
+	}
+
+	sb := strings.Builder{}
+	err := htmlPageTemplate.Execute(&sb, data)
+
+	return sb.String(), err
+}
+
+func htmlIndex(root *docPage, ext string) (string, error) {
+	data := struct {
+		docLink
+		Graph string
+		Pages []docLink
+	}{
+		docLink: docLinkOf(root, ext, 0),
+		Graph:   root.graph(),
+	}
+
+	root.walk(0, func(p *docPage, depth int) {
+		data.Pages = append(data.Pages, docLinkOf(p, ext, depth))
+	})
+
+	sb := strings.Builder{}
+	err := htmlIndexTemplate.Execute(&sb, data)
+
+	return sb.String(), err
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDocsTestCLI() *CLI {
+	return New(
+		Name("gurl"),
+		Description("A simple CLI for making HTTP requests"),
+		Command("get",
+			Description("Get a resource"),
+			Example("gurl get http://example.com"),
+			Argument("url",
+				Description("The URL to get"),
+				Validate(regexp.MustCompile(`^https?://.+$`)),
+				Option("verbose", Short('v'), Description("Print more | less")),
+			),
+		),
+		Command("config",
+			Command("set", Description("Set a config value")),
+		),
+	)
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, newDocsTestCLI().GenerateMarkdown(dir))
+
+	for _, file := range []string{"index.md", "gurl.md", "gurl_get.md", "gurl_config.md", "gurl_config_set.md"} {
+		assert.FileExists(t, filepath.Join(dir, file))
+	}
+
+	get, err := os.ReadFile(filepath.Join(dir, "gurl_get.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(get), "# gurl get\n\nGet a resource\n")
+	assert.Contains(t, string(get), "gurl get <url> [options...]")
+	assert.Contains(t, string(get), "| `<url>` | The URL to get | `^https?://.+$` |")
+	assert.Contains(t, string(get), "| `--verbose` | `-v` | Print more \\| less |  |  |")
+	assert.Contains(t, string(get), "gurl get http://example.com")
+	assert.Contains(t, string(get), "* [gurl](gurl.md)")
+
+	config, err := os.ReadFile(filepath.Join(dir, "gurl_config.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(config), "* [gurl config set](gurl_config_set.md) - Set a config value")
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "```mermaid\nflowchart LR\n")
+	assert.Contains(t, string(index), "n1 --> n2([\"url\"])")
+	assert.Contains(t, string(index), "    * [gurl config set](gurl_config_set.md)")
+}
+
+func TestGenerateHTML(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, newDocsTestCLI().GenerateHTML(dir))
+
+	get, err := os.ReadFile(filepath.Join(dir, "gurl_get.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(get), "<h1>gurl get</h1>")
+	assert.Contains(t, string(get), "<pre>gurl get &lt;url&gt; [options...]</pre>")
+	assert.Contains(t, string(get), "<a href=\"gurl.html\">gurl</a>")
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "<pre class=\"mermaid\">")
+	assert.Contains(t, string(index), "<a href=\"gurl_config_set.html\">gurl config set</a>")
+}
+
+func TestGenerateDocsClash(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Index", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		err := New(Name("index"), Command("get")).GenerateMarkdown(dir)
+
+		assert.EqualError(t, err, `the page of "index" would overwrite the index in index.md`)
+		assert.NoFileExists(t, filepath.Join(dir, "index_get.md"))
+	})
+
+	t.Run("Commands", func(t *testing.T) {
+		t.Parallel()
+
+		err := New(Name("gurl"), Command("a", Command("b")), Command("a_b")).GenerateHTML(t.TempDir())
+
+		assert.EqualError(t, err, `the page of "gurl a_b" would overwrite the page of "gurl a b" in gurl_a_b.html`)
+	})
+}
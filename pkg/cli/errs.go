@@ -2,6 +2,7 @@ package cli
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
 )
@@ -9,6 +10,25 @@ import (
 var ErrNotMatched = errors.New("not matched")
 var ErrUnexpectedEndCommand = errors.New("unexpected end of command")
 
+// ErrHelp is matched by the *HelpError returned from RunWith when help was
+// requested and printed.
+var ErrHelp = errors.New("help requested")
+
+// ErrVersion is returned from RunWith after the version was printed
+// because of the --version flag.
+var ErrVersion = errors.New("version requested")
+
+// ErrConfirmationRequired is returned if a command with Confirm runs
+// without --yes and the confirmation can not be prompted for.
+var ErrConfirmationRequired = errors.New("confirmation required, use --yes to continue")
+
+// ErrNotConfirmed is returned if the confirmation of a command was declined.
+var ErrNotConfirmed = errors.New("not confirmed")
+
+// ErrUnterminatedQuote is returned for a line with a quote that is not
+// closed, e.g. in RunShell.
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
 type DuplicateCommandError string
 
 func (e DuplicateCommandError) Error() string {
@@ -27,6 +47,20 @@ func (e UnknownCommandError) Error() string {
 	return "unknown command: " + string(e)
 }
 
+// AliasShadowsCommandError is returned when defining an alias named like a
+// command.
+type AliasShadowsCommandError string
+
+func (e AliasShadowsCommandError) Error() string {
+	return "alias shadows command: " + string(e)
+}
+
+type UnknownAliasError string
+
+func (e UnknownAliasError) Error() string {
+	return "unknown alias: " + string(e)
+}
+
 type UnknownArgumentError string
 
 func (e UnknownArgumentError) Error() string {
@@ -50,3 +84,54 @@ type HelpError struct {
 func (e HelpError) Error() string {
 	return "help"
 }
+
+func (e HelpError) Is(target error) bool {
+	return target == ErrHelp
+}
+
+// Path returns the commands and arguments leading to the node the help was
+// requested for, excluding the name of the CLI.
+func (e HelpError) Path() []string {
+	return strings.Fields(e.backtrack)
+}
+
+// ParseError describes an error in the command line. It wraps the cause,
+// e.g. an UnknownCommandError, an *InvalidValueError or
+// ErrUnexpectedEndCommand, so errors.Is and errors.As match the cause.
+type ParseError struct {
+	// Path holds the commands and arguments matched before the error.
+	Path []string
+	// Token is the offending argument. It is empty if the command line
+	// ended unexpectedly.
+	Token string
+	// Index is the position of Token in the arguments passed to RunWith,
+	// or their length if the command line ended unexpectedly.
+	Index int
+	// Expected describes what was expected at the position of Token,
+	// e.g. "command" or "argument <url>".
+	Expected string
+	Err      error
+
+	// secrets holds the values of secret options, which are redacted in
+	// the diagnostic.
+	secrets []string
+}
+
+func newParseError(ctx *Context, token string, index int, expected string, err error) *ParseError {
+	return &ParseError{
+		Path:     append([]string{}, ctx.path...),
+		Token:    token,
+		Index:    index,
+		Expected: expected,
+		Err:      err,
+		secrets:  ctx.secretValues(),
+	}
+}
+
+func (e *ParseError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -40,3 +41,42 @@ func TestMixOfArgumentAndCommandError(t *testing.T) {
 	assert(err.Error() == expected,
 		"expected '"+expected+"', got '"+err.Error()+"'")
 }
+
+func TestHelpError(t *testing.T) {
+	err := error(&HelpError{backtrack: " get url"})
+
+	if !errors.Is(err, ErrHelp) {
+		t.Errorf("expected help error to match ErrHelp")
+	}
+
+	path := (&HelpError{backtrack: " get url"}).Path()
+	if len(path) != 2 || path[0] != "get" || path[1] != "url" {
+		t.Errorf("expected path [get url], got %v", path)
+	}
+}
+
+// parseErrorCause asserts that err is a *ParseError and returns its cause.
+func parseErrorCause(t *testing.T, err error) error {
+	t.Helper()
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *ParseError, got %T: %v", err, err)
+	}
+
+	return parseErr.Err
+}
+
+func TestParseError(t *testing.T) {
+	cause := UnknownCommandError("x")
+	err := error(&ParseError{Token: "x", Err: cause})
+
+	if err.Error() != "unknown command: x" {
+		t.Errorf("expected cause message, got '%s'", err.Error())
+	}
+
+	var unknown UnknownCommandError
+	if !errors.As(err, &unknown) || unknown != cause {
+		t.Errorf("expected parse error to wrap the cause")
+	}
+}
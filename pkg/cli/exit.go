@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Exit codes used by MustRun and MustRunWith.
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	// ExitUsage is used for errors in the command line, e.g. unknown
+	// commands or invalid values.
+	ExitUsage = 2
+	// ExitInterrupted is used if the CLI is forced to exit by a second
+	// interrupt or the end of the grace period, see GracePeriod.
+	ExitInterrupted = 130
+)
+
+// ExitCoder is implemented by errors that define the exit code of the
+// process when they are returned from a handler.
+type ExitCoder interface {
+	error
+	ExitCode() int
+}
+
+// ExitError wraps an error with the exit code MustRun exits with.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// NewExitError wraps err with the given exit code.
+func NewExitError(code int, err error) *ExitError {
+	return &ExitError{Code: code, Err: err}
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return "exit status " + strconv.Itoa(e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+// exitCodeMapping maps errors matched by match to code.
+type exitCodeMapping struct {
+	match func(err error) bool
+	code  int
+}
+
+// exitCode returns the exit code for err. An ExitCoder in the chain of err
+// takes precedence over the mappings registered with ExitCodeFor, which in
+// turn take precedence over ExitUsage for command line errors.
+func (c *CLI) exitCode(err error) int {
+	if err == nil || errors.Is(err, ErrHelp) || errors.Is(err, ErrVersion) {
+		return ExitOK
+	}
+
+	var exitCoder ExitCoder
+	if errors.As(err, &exitCoder) {
+		return exitCoder.ExitCode()
+	}
+
+	for _, mapping := range c.exitCodes {
+		if mapping.match(err) {
+			return mapping.code
+		}
+	}
+
+	if isUsageError(err) {
+		return ExitUsage
+	}
+
+	return ExitFailure
+}
+
+// isUsageError reports whether err was caused by an invalid command line.
+func isUsageError(err error) bool {
+	var (
+		unknownCommand  UnknownCommandError
+		unknownArgument UnknownArgumentError
+		invalidValue    *InvalidValueError
+	)
+
+	return errors.Is(err, ErrUnexpectedEndCommand) ||
+		errors.Is(err, ErrConfirmationRequired) ||
+		errors.Is(err, ErrUnterminatedQuote) ||
+		errors.As(err, &unknownCommand) ||
+		errors.As(err, &unknownArgument) ||
+		errors.As(err, &invalidValue)
+}
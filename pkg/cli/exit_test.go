@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type quotaError struct{}
+
+func (quotaError) Error() string { return "quota exceeded" }
+
+func TestExitError(t *testing.T) {
+	t.Parallel()
+
+	cause := errors.New("failed")
+	err := NewExitError(3, cause)
+
+	assert.Equal(t, "failed", err.Error())
+	assert.Equal(t, 3, err.ExitCode())
+	assert.ErrorIs(t, err, cause)
+	assert.Equal(t, "exit status 4", NewExitError(4, nil).Error())
+}
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	cli := New(
+		ExitCodeFor[quotaError](75),
+		ExitCodeFor[UnknownCommandError](64),
+	)
+
+	for name, tc := range map[string]struct {
+		err  error
+		code int
+	}{
+		"Nil":            {nil, ExitOK},
+		"Help":           {&HelpError{}, ExitOK},
+		"Version":        {ErrVersion, ExitOK},
+		"Generic":        {errors.New("failed"), ExitFailure},
+		"ExitError":      {NewExitError(5, errors.New("failed")), 5},
+		"WrappedExit":    {fmt.Errorf("wrapped: %w", NewExitError(6, nil)), 6},
+		"Registered":     {fmt.Errorf("wrapped: %w", quotaError{}), 75},
+		"RegisteredKeep": {UnknownCommandError("x"), 64},
+		"UnexpectedEnd":  {ErrUnexpectedEndCommand, ExitUsage},
+		"InvalidValue":   {&InvalidValueError{on: "opt", value: "x"}, ExitUsage},
+		"ExitWinsOver":   {NewExitError(7, quotaError{}), 7},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.code, cli.exitCode(tc.err))
+		})
+	}
+
+	t.Run("InvalidOption", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "Invalid type for ExitCode option", func() {
+			New(&options.ExitCode{Match: "invalid"})
+		})
+	})
+
+	t.Run("MustRunWithHandlerExitCode", func(t *testing.T) {
+		t.Parallel()
+
+		code := -1
+		cli := New(
+			Stream(io.Discard, io.Discard),
+			ExitFunc(func(c int) { code = c }),
+			Handler(func(ctx *Context) error { return NewExitError(42, errors.New("failed")) }),
+		)
+		cli.MustRunWith([]string{"cli"})
+
+		assert.Equal(t, 42, code)
+	})
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"slices"
+	"strings"
+)
+
+// globalValueFlags are the global flags taking a value along with the
+// values they accept when separated, nil for any.
+var globalValueFlags = map[string][]string{"--color": colorModes, "--output": nil, "--batch": nil}
+
+// flagArgs holds the arguments the global flags are extracted from along
+// with the index in argv each of them stems from, so errors can point at
+// the right argument of argv after flags were removed.
+type flagArgs struct {
+	root   node
+	argc   int
+	args   []string
+	origin []int
+}
+
+// newFlagArgs returns the arguments of argv without the program name. A
+// flag is not taken as global flag where the node of root the preceding
+// arguments lead to declares an option of the same name.
+func newFlagArgs(root node, argv []string) *flagArgs {
+	f := &flagArgs{root: root, argc: len(argv), args: append([]string{}, argv[1:]...)}
+	for i := range f.args {
+		f.origin = append(f.origin, i+1)
+	}
+
+	return f
+}
+
+// argvIndex maps the index i of the remaining arguments to the index of
+// the same argument in argv, or to len(argv) past their end.
+func (f *flagArgs) argvIndex(i int) int {
+	if i < 0 || i >= len(f.origin) {
+		return f.argc
+	}
+
+	return f.origin[i]
+}
+
+// declares reports whether the node the arguments before index i lead to
+// declares the option name.
+func (f *flagArgs) declares(i int, name string) bool {
+	n := f.root
+	for j := 0; j < i; j++ {
+		arg := f.args[j]
+		if values, ok := globalValueFlags[arg]; ok {
+			if j+1 < i && (values == nil || slices.Contains(values, f.args[j+1])) {
+				j++
+			}
+			continue
+		} else if strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		if n.argument != nil {
+			n = n.argument.node()
+			continue
+		}
+		index := slices.IndexFunc(n.command, func(cmd *command) bool { return cmd.name == arg })
+		if index < 0 {
+			break
+		}
+		n = n.command[index].node()
+	}
+
+	return slices.ContainsFunc(n.options, func(opt *option) bool { return opt.long == name })
+}
+
+func (f *flagArgs) remove(i, n int) {
+	f.args = append(f.args[:i:i], f.args[i+n:]...)
+	f.origin = append(f.origin[:i:i], f.origin[i+n:]...)
+}
+
+// extractFlag removes the first "--name value" or "--name=value" and
+// returns the value along with its index in argv, which is len(argv) if
+// the value is missing. Only the arguments before a "--" terminator are
+// considered and those the matched node declares are skipped. If values
+// are given, "--name" only takes the next argument as value if it is one
+// of them and is left without value otherwise.
+func (f *flagArgs) extractFlag(name string, values ...string) (string, int, bool) {
+	for i, arg := range f.args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "--"+name) || f.declares(i, name) {
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(arg, "--"+name+"="); ok {
+			index := f.origin[i]
+			f.remove(i, 1)
+			return value, index, true
+		}
+
+		if arg == "--"+name {
+			if i+1 < len(f.args) && (len(values) == 0 || slices.Contains(values, f.args[i+1])) {
+				value, index := f.args[i+1], f.origin[i+1]
+				f.remove(i, 2)
+				return value, index, true
+			}
+
+			f.remove(i, 1)
+			return "", f.argc, true
+		}
+	}
+
+	return "", f.argc, false
+}
+
+// extractSwitch removes all occurrences of the value-less flag "--name"
+// and reports whether there was one. Only the arguments before a "--"
+// terminator are considered and those the matched node declares are
+// skipped.
+func (f *flagArgs) extractSwitch(name string) bool {
+	found := false
+	for i := 0; i < len(f.args) && f.args[i] != "--"; {
+		if f.args[i] == "--"+name && !f.declares(i, name) {
+			found = true
+			f.remove(i, 1)
+			continue
+		}
+		i++
+	}
+
+	return found
+}
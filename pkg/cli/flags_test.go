@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractFlag(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		args      []string
+		remaining []string
+		origin    []int
+		value     string
+		index     int
+		found     bool
+	}{
+		"NotPresent":      {[]string{"get", "-v"}, []string{"get", "-v"}, []int{1, 2}, "", 3, false},
+		"Separated":       {[]string{"get", "--flag", "x", "-v"}, []string{"get", "-v"}, []int{1, 4}, "x", 3, true},
+		"Assigned":        {[]string{"--flag=x", "get"}, []string{"get"}, []int{2}, "x", 1, true},
+		"MissingValue":    {[]string{"get", "--flag"}, []string{"get"}, []int{1}, "", 3, true},
+		"AfterTerminator": {[]string{"get", "--", "--flag=x"}, []string{"get", "--", "--flag=x"}, []int{1, 2, 3}, "", 4, false},
+		"RepeatedValue":   {[]string{"--flag", "x", "x"}, []string{"x"}, []int{3}, "x", 2, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			args := newFlagArgs(node{}, append([]string{"t"}, tc.args...))
+			value, index, found := args.extractFlag("flag")
+			assert.Equal(t, tc.remaining, args.args)
+			assert.Equal(t, tc.origin, args.origin)
+			assert.Equal(t, tc.value, value)
+			assert.Equal(t, tc.index, index)
+			assert.Equal(t, tc.found, found)
+		})
+	}
+}
+
+func TestExtractSwitch(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		args      []string
+		remaining []string
+		origin    []int
+		found     bool
+	}{
+		"NotPresent":      {[]string{"get", "-v"}, []string{"get", "-v"}, []int{1, 2}, false},
+		"Present":         {[]string{"--flag", "get", "--flag"}, []string{"get"}, []int{2}, true},
+		"AfterTerminator": {[]string{"get", "--", "--flag"}, []string{"get", "--", "--flag"}, []int{1, 2, 3}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			args := newFlagArgs(node{}, append([]string{"t"}, tc.args...))
+			found := args.extractSwitch("flag")
+			assert.Equal(t, tc.remaining, args.args)
+			assert.Equal(t, tc.origin, args.origin)
+			assert.Equal(t, tc.found, found)
+		})
+	}
+}
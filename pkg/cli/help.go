@@ -0,0 +1,351 @@
+package cli
+
+import (
+	"io"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/text"
+)
+
+// HelpData is the resolved information about the CLI, command or argument
+// the help was requested for. It is passed to a HelpRenderer.
+type HelpData struct {
+	Name        string
+	Version     string
+	Banner      string
+	Description string
+	Example     string
+	// Path holds the commands and arguments leading to the described node,
+	// excluding the name of the CLI.
+	Path     []string
+	Argument *HelpArgument
+	Commands []HelpCommand
+	// Plugins holds the commands provided by plugins, see Plugins.
+	Plugins []HelpCommand
+	// Aliases holds the aliases defined by the user, see Aliases. Their
+	// description is the expansion.
+	Aliases []HelpCommand
+	Options []HelpOption
+	// Groups holds the commands and options as titled name/description
+	// listings, in the order they are shown by the default renderer.
+	Groups []HelpGroup
+	// Width is the column count the help should be wrapped to.
+	Width int
+	// Theme holds the styles to apply. It is the zero Theme if the output
+	// should not be styled.
+	Theme Theme
+
+	translator translator
+}
+
+// HelpArgument describes the argument expected at the current position.
+type HelpArgument struct {
+	Name        string
+	Description string
+	Example     string
+	Pattern     string
+}
+
+// HelpCommand describes a command that can follow the current position.
+type HelpCommand struct {
+	Name        string
+	Description string
+	Example     string
+}
+
+// HelpOption describes an option accepted at the current position.
+type HelpOption struct {
+	Long        string
+	Short       string
+	Description string
+	Default     string
+	Pattern     string
+	Required    bool
+}
+
+// HelpGroup is a titled listing of help entries, e.g. "Commands" or "Options".
+type HelpGroup struct {
+	Title   string
+	Entries []HelpEntry
+	// Style is the style of the entry names.
+	Style Style
+}
+
+// HelpEntry is a single line of a HelpGroup.
+type HelpEntry struct {
+	Name        string
+	Description string
+	Example     string
+}
+
+// Usage returns the full command line up to the described node,
+// e.g. "gurl get".
+func (d *HelpData) Usage() string {
+	return strings.Join(append([]string{d.Name}, d.Path...), " ")
+}
+
+// Message returns the translation of one of the Message* keys for the
+// selected locale, formatted with args, e.g. {{.Message "help.usage"}}.
+func (d *HelpData) Message(key string, args ...interface{}) string {
+	return d.translator.message(key, args...)
+}
+
+// HelpRenderer writes the help message described by data to w.
+type HelpRenderer interface {
+	Render(w io.Writer, data *HelpData) error
+}
+
+const (
+	// helpIndent is the indentation of entries below a heading.
+	helpIndent = 2
+	// helpGap is the minimal space between the two columns of a listing.
+	helpGap = 2
+)
+
+// DefaultHelpRenderer renders the built-in help layout. Text is wrapped to
+// HelpData.Width and listings are laid out in two aligned columns.
+type DefaultHelpRenderer struct{}
+
+func (DefaultHelpRenderer) Render(w io.Writer, data *HelpData) error {
+	sb := strings.Builder{}
+	width := data.Width
+	if width <= 0 {
+		width = defaultWidth
+	}
+
+	if data.Banner != "" {
+		sb.WriteString(data.Banner + "\n\n")
+	}
+	if data.Version != "" {
+		sb.WriteString(data.Version + "\n\n")
+	}
+	if data.Description != "" {
+		sb.WriteString(strings.Join(text.Wrap(data.Description, width), "\n") + "\n\n")
+	}
+
+	usage := data.Usage()
+	if data.Argument != nil {
+		usage += " <argument>"
+	} else if len(data.Commands) > 0 {
+		usage += " <command>"
+	}
+	if len(data.Options) > 0 {
+		usage += " [options...]"
+	}
+	usage = text.Indent(usage, width, helpIndent*2)
+	for _, placeholder := range []string{"<argument>", "<command>", "[options...]"} {
+		usage = strings.Replace(usage, placeholder, data.Theme.Placeholder.Apply(placeholder), 1)
+	}
+	sb.WriteString(data.Theme.Heading.Apply(data.Message(MessageUsage)) + "\n" + indent(helpIndent) + usage + "\n")
+
+	for _, group := range data.Groups {
+		sb.WriteString("\n")
+		writeHelpGroup(&sb, group, width, data)
+	}
+
+	if data.Example != "" {
+		sb.WriteString("\n" + data.Theme.Heading.Apply(data.Message(MessageExample)) + "\n" +
+			indent(helpIndent) + text.Indent(data.Example, width, helpIndent) + "\n")
+	}
+
+	sb.WriteString("\n" + strings.Join(text.Wrap(data.Message(MessageMoreHelp, data.Name), width), "\n") + "\n")
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// writeHelpGroup writes the entries of group as two aligned columns. The
+// description column starts after the longest name, but at most at a third
+// of width. Longer names put their description on the following line.
+func writeHelpGroup(sb *strings.Builder, group HelpGroup, width int, data *HelpData) {
+	sb.WriteString(data.Theme.Heading.Apply(group.Title+":") + "\n")
+
+	column := 0
+	for _, entry := range group.Entries {
+		column = max(column, utf8.RuneCountInString(entry.Name))
+	}
+	column = min(column, width/3) + helpIndent + helpGap
+
+	for _, entry := range group.Entries {
+		length := helpIndent + utf8.RuneCountInString(entry.Name)
+		line := indent(helpIndent) + group.Style.Apply(entry.Name)
+		description := entry.Description
+		if entry.Example != "" {
+			if description != "" {
+				description += "\n"
+			}
+			description += data.Message(MessageExample) + " " + entry.Example
+		}
+
+		if description == "" {
+			sb.WriteString(line + "\n")
+			continue
+		}
+
+		if length+helpGap > column {
+			sb.WriteString(line + "\n" + indent(column))
+		} else {
+			sb.WriteString(line + indent(column-length))
+		}
+		sb.WriteString(text.Indent(description, width, column) + "\n")
+	}
+}
+
+func indent(n int) string {
+	return strings.Repeat(" ", n)
+}
+
+// TemplateHelpRenderer renders the help message with a text/template.
+// The template is executed with a *HelpData.
+type TemplateHelpRenderer struct {
+	template *template.Template
+}
+
+// NewTemplateHelpRenderer parses content into a TemplateHelpRenderer.
+// Besides the builtin template functions, "join" (strings.Join),
+// "upper", "lower", "repeat" (strings.Repeat) and "wrap" are available.
+// wrap takes a text, the width and the hanging indent of continuation
+// lines, e.g. {{wrap .Description .Width 4}}.
+func NewTemplateHelpRenderer(content string) (*TemplateHelpRenderer, error) {
+	tmpl, err := template.New("help").Funcs(template.FuncMap{
+		"join":   strings.Join,
+		"upper":  strings.ToUpper,
+		"lower":  strings.ToLower,
+		"repeat": strings.Repeat,
+		"wrap":   text.Indent,
+	}).Parse(content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateHelpRenderer{template: tmpl}, nil
+}
+
+func (r *TemplateHelpRenderer) Render(w io.Writer, data *HelpData) error {
+	return r.template.Execute(w, data)
+}
+
+// helpData resolves the node the help was requested for into HelpData,
+// styled for the color mode.
+func (c *CLI) helpData(helpError *HelpError, color string) *HelpData {
+	n := c.node()
+	t := c.translator()
+	data := &HelpData{
+		Name:       c.Name(),
+		Width:      c.terminalWidth(),
+		Theme:      c.themeFor(c.stdout, color),
+		translator: t,
+	}
+
+	if c.banner != nil {
+		data.Banner = *c.banner
+	}
+	if c.version != nil {
+		data.Version = *c.version
+	}
+
+	if helpError != nil && helpError.on != nil {
+		switch v := helpError.on.(type) {
+		case *argument:
+			data.Path = helpError.Path()
+			n = v.node()
+			n.argument = v
+		case *command:
+			data.Path = helpError.Path()
+			n = v.node()
+		}
+	}
+
+	if n.description != nil {
+		data.Description = t.text(*n.description)
+	}
+	if n.example != nil {
+		data.Example = t.text(*n.example)
+	}
+
+	if n.argument != nil {
+		data.Argument = &HelpArgument{Name: n.argument.name}
+		if n.argument.description != nil {
+			data.Argument.Description = t.text(*n.argument.description)
+		}
+		if n.argument.example != nil {
+			data.Argument.Example = t.text(*n.argument.example)
+		}
+		if n.argument.validate != nil {
+			data.Argument.Pattern = n.argument.validate.String()
+		}
+	} else if len(n.command) > 0 {
+		group := HelpGroup{Title: t.message(MessageCommands), Style: data.Theme.Command}
+		for _, cmd := range n.command {
+			helpCmd := HelpCommand{Name: cmd.name}
+			if cmd.description != nil {
+				helpCmd.Description = t.text(*cmd.description)
+			}
+			if cmd.example != nil {
+				helpCmd.Example = t.text(*cmd.example)
+			}
+			data.Commands = append(data.Commands, helpCmd)
+			group.Entries = append(group.Entries, HelpEntry{
+				Name:        helpCmd.Name,
+				Description: helpCmd.Description,
+				Example:     helpCmd.Example,
+			})
+		}
+		data.Groups = append(data.Groups, group)
+	}
+
+	if c.plugins && (helpError == nil || helpError.on == nil) {
+		group := HelpGroup{Title: t.message(MessagePlugins), Style: data.Theme.Command}
+		for _, name := range c.discoverPlugins() {
+			data.Plugins = append(data.Plugins, HelpCommand{Name: name})
+			group.Entries = append(group.Entries, HelpEntry{Name: name})
+		}
+		if len(group.Entries) > 0 {
+			data.Groups = append(data.Groups, group)
+		}
+	}
+
+	if c.aliasFile != nil && (helpError == nil || helpError.on == nil) {
+		group := HelpGroup{Title: t.message(MessageAliases), Style: data.Theme.Command}
+		aliases, _ := c.readAliases()
+		for _, name := range c.aliasNames(aliases) {
+			data.Aliases = append(data.Aliases, HelpCommand{Name: name, Description: aliases[name]})
+			group.Entries = append(group.Entries, HelpEntry{Name: name, Description: aliases[name]})
+		}
+		if len(group.Entries) > 0 {
+			data.Groups = append(data.Groups, group)
+		}
+	}
+
+	if len(n.options) > 0 {
+		group := HelpGroup{Title: t.message(MessageOptions), Style: data.Theme.Option}
+		for _, opt := range n.options {
+			helpOpt := HelpOption{Long: opt.long, Required: opt.required}
+			if opt.short != nil {
+				helpOpt.Short = string(*opt.short)
+			}
+			if opt.description != nil {
+				helpOpt.Description = t.text(*opt.description)
+			}
+			if opt.defaultValue != nil && !opt.secret {
+				helpOpt.Default = *opt.defaultValue
+			}
+			if opt.validate != nil {
+				helpOpt.Pattern = opt.validate.String()
+			}
+			data.Options = append(data.Options, helpOpt)
+
+			name := "--" + helpOpt.Long
+			if helpOpt.Short != "" {
+				name += ", -" + helpOpt.Short
+			}
+			group.Entries = append(group.Entries, HelpEntry{Name: name, Description: helpOpt.Description})
+		}
+		data.Groups = append(data.Groups, group)
+	}
+
+	return data
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelpData(t *testing.T) {
+	t.Parallel()
+
+	get := Command("get",
+		Description("Get a resource"),
+		Example("gurl get http://example.com"),
+		Argument("url", Description("The URL to get")),
+	)
+	cli := New(
+		Name("gurl"),
+		Version("1.0.0"),
+		Description("A simple CLI"),
+		get,
+		Command("post"),
+	)
+
+	t.Run("Root", func(t *testing.T) {
+		t.Parallel()
+
+		data := cli.helpData(nil, ColorAuto)
+		assert.Equal(t, "gurl", data.Name)
+		assert.Equal(t, "1.0.0", data.Version)
+		assert.Equal(t, "A simple CLI", data.Description)
+		assert.Empty(t, data.Path)
+		assert.Equal(t, "gurl", data.Usage())
+		assert.Nil(t, data.Argument)
+		assert.Equal(t, []HelpCommand{
+			{Name: "get", Description: "Get a resource", Example: "gurl get http://example.com"},
+			{Name: "post"},
+		}, data.Commands)
+		require.Len(t, data.Groups, 1)
+		assert.Equal(t, "Commands", data.Groups[0].Title)
+		assert.Equal(t, HelpEntry{
+			Name: "get", Description: "Get a resource", Example: "gurl get http://example.com",
+		}, data.Groups[0].Entries[0])
+	})
+
+	t.Run("Command", func(t *testing.T) {
+		t.Parallel()
+
+		data := cli.helpData(&HelpError{on: get, backtrack: " get"}, ColorAuto)
+		assert.Equal(t, []string{"get"}, data.Path)
+		assert.Equal(t, "gurl get", data.Usage())
+		assert.Equal(t, "Get a resource", data.Description)
+		assert.Equal(t, &HelpArgument{Name: "url", Description: "The URL to get"}, data.Argument)
+		assert.Empty(t, data.Commands)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Option("verbose", Short('v'), Description("Verbose output"), Default("no"), Required()))
+		data := cli.helpData(nil, ColorAuto)
+		assert.Equal(t, []HelpOption{
+			{Long: "verbose", Short: "v", Description: "Verbose output", Default: "no", Required: true},
+		}, data.Options)
+		assert.Equal(t, []HelpGroup{
+			{Title: "Options", Entries: []HelpEntry{{Name: "--verbose, -v", Description: "Verbose output"}}},
+		}, data.Groups)
+	})
+}
+
+func TestDefaultHelpRenderer(t *testing.T) {
+	t.Parallel()
+
+	data := &HelpData{
+		Name:        "gurl",
+		Description: "A simple CLI for making HTTP requests from the terminal",
+		Commands:    []HelpCommand{{Name: "get"}},
+		Options:     []HelpOption{{Long: "verbose"}},
+		Groups: []HelpGroup{
+			{Title: "Commands", Entries: []HelpEntry{
+				{Name: "get", Description: "Get a resource", Example: "gurl get x"},
+				{Name: "post"},
+			}},
+			{Title: "Options", Entries: []HelpEntry{
+				{Name: "--verbose, -v", Description: "Print request and response headers"},
+				{Name: "--a-very-long-option-name", Description: "Too long to align"},
+			}},
+		},
+		Width: 40,
+	}
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, DefaultHelpRenderer{}.Render(buf, data))
+	assert.Equal(t, `A simple CLI for making HTTP requests
+from the terminal
+
+Usage:
+  gurl <command> [options...]
+
+Commands:
+  get   Get a resource
+        Example: gurl get x
+  post
+
+Options:
+  --verbose, -v  Print request and
+                 response headers
+  --a-very-long-option-name
+                 Too long to align
+
+Use "gurl <command> --help" for more
+information about a command.
+`, buf.String())
+}
+
+func TestTemplateHelpRenderer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Render", func(t *testing.T) {
+		t.Parallel()
+
+		renderer, err := NewTemplateHelpRenderer(
+			`{{upper .Usage}}{{range .Groups}}|{{.Title}}:{{range .Entries}} {{.Name}}{{end}}{{end}}`)
+		require.NoError(t, err)
+
+		buf := &bytes.Buffer{}
+		require.NoError(t, renderer.Render(buf, &HelpData{
+			Name:   "gurl",
+			Path:   []string{"get"},
+			Groups: []HelpGroup{{Title: "Options", Entries: []HelpEntry{{Name: "--verbose"}}}},
+		}))
+		assert.Equal(t, "GURL GET|Options: --verbose", buf.String())
+	})
+
+	t.Run("InvalidTemplate", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewTemplateHelpRenderer("{{")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,120 @@
+package cli
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
+
+// PostRunFunc is called after the handler with the error it returned.
+// The returned error replaces the handler's error.
+type PostRunFunc func(ctx *Context, err error) error
+
+// hooks holds the lifecycle hooks of the CLI, a command or an argument.
+// Persistent hooks also run for every descendant.
+type hooks struct {
+	preRun            *HandlerFunc
+	postRun           *PostRunFunc
+	persistentPreRun  *HandlerFunc
+	persistentPostRun *PostRunFunc
+	middleware        []Middleware
+	// confirm is the question asked before the handlers below, see Confirm.
+	confirm *string
+}
+
+func (h *hooks) setPreRun(v *options.PreRun) {
+	handlerFunc, ok := v.Handler.(HandlerFunc)
+	if !ok {
+		panic("Invalid type for PreRun option")
+	}
+
+	if v.Persistent {
+		h.persistentPreRun = &handlerFunc
+	} else {
+		h.preRun = &handlerFunc
+	}
+}
+
+func (h *hooks) setPostRun(v *options.PostRun) {
+	postRunFunc, ok := v.Handler.(PostRunFunc)
+	if !ok {
+		panic("Invalid type for PostRun option")
+	}
+
+	if v.Persistent {
+		h.persistentPostRun = &postRunFunc
+	} else {
+		h.postRun = &postRunFunc
+	}
+}
+
+func (h *hooks) use(v *options.Middleware) {
+	for _, mw := range v.Middleware {
+		middleware, ok := mw.(Middleware)
+		if !ok {
+			panic("Invalid type for Middleware option")
+		}
+		h.middleware = append(h.middleware, middleware)
+	}
+}
+
+// execute runs the handler of the matched leaf surrounded by the hooks:
+// the persistent pre-run hooks from the root down to the leaf, the leaf's
+// pre-run hook, the handler, the leaf's post-run hook and the persistent
+// post-run hooks from the leaf up to the root. If a pre-run hook fails, the
+// handler is skipped and the post-run hooks see that error.
+// All of that is wrapped by the middleware of the lineage, the middleware
+// closest to the root being the outermost.
+func (ctx *Context) execute(leaf *hooks, handler *HandlerFunc) error {
+	if handler == nil {
+		return nil
+	}
+
+	run := func(ctx *Context) error {
+		return ctx.run(leaf, *handler)
+	}
+	for i := len(ctx.lineage) - 1; i >= 0; i-- {
+		middleware := ctx.lineage[i].middleware
+		for j := len(middleware) - 1; j >= 0; j-- {
+			run = middleware[j](run)
+		}
+	}
+
+	return run(ctx)
+}
+
+// run calls handler surrounded by the hooks of the lineage. It returns
+// without calling anything if a confirmation was declined.
+func (ctx *Context) run(leaf *hooks, handler HandlerFunc) error {
+	if err := ctx.confirm(); err != nil {
+		return err
+	}
+
+	err := ctx.preRun(leaf)
+	if err == nil {
+		err = handler(ctx)
+	}
+
+	if leaf.postRun != nil {
+		err = (*leaf.postRun)(ctx, err)
+	}
+	for i := len(ctx.lineage) - 1; i >= 0; i-- {
+		if postRun := ctx.lineage[i].persistentPostRun; postRun != nil {
+			err = (*postRun)(ctx, err)
+		}
+	}
+
+	return err
+}
+
+func (ctx *Context) preRun(leaf *hooks) error {
+	for _, h := range ctx.lineage {
+		if h.persistentPreRun != nil {
+			if err := (*h.persistentPreRun)(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if leaf.preRun != nil {
+		return (*leaf.preRun)(ctx)
+	}
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHooks(t *testing.T) {
+	t.Parallel()
+
+	record := func(calls *[]string, name string) HandlerFunc {
+		return func(ctx *Context) error {
+			*calls = append(*calls, name)
+			return nil
+		}
+	}
+	recordPost := func(calls *[]string, name string) PostRunFunc {
+		return func(ctx *Context, err error) error {
+			*calls = append(*calls, name)
+			return err
+		}
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		t.Parallel()
+
+		calls := []string{}
+		cli := New(
+			PersistentPreRun(record(&calls, "root persistent pre")),
+			PersistentPostRun(recordPost(&calls, "root persistent post")),
+			PreRun(record(&calls, "root pre")),
+			Command("db",
+				PersistentPreRun(record(&calls, "db persistent pre")),
+				PersistentPostRun(recordPost(&calls, "db persistent post")),
+				PreRun(record(&calls, "db pre")),
+				PostRun(recordPost(&calls, "db post")),
+				Argument("name",
+					PreRun(record(&calls, "name pre")),
+					PostRun(recordPost(&calls, "name post")),
+					Handler(record(&calls, "handler")),
+				),
+			),
+		)
+		_, err := cli.RunWith([]string{"cli", "db", "users"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"root persistent pre",
+			"db persistent pre",
+			"name pre",
+			"handler",
+			"name post",
+			"db persistent post",
+			"root persistent post",
+		}, calls)
+	})
+
+	t.Run("PostRunSeesHandlerError", func(t *testing.T) {
+		t.Parallel()
+
+		handlerErr := errors.New("handler failed")
+		var seen error
+		cli := New(
+			Handler(func(ctx *Context) error { return handlerErr }),
+			PostRun(func(ctx *Context, err error) error {
+				seen = err
+				return nil
+			}),
+		)
+		ctx, err := cli.RunWith([]string{"cli"})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, ctx)
+		assert.Equal(t, handlerErr, seen)
+	})
+
+	t.Run("PreRunErrorSkipsHandler", func(t *testing.T) {
+		t.Parallel()
+
+		preErr := errors.New("not authenticated")
+		calls := []string{}
+		cli := New(
+			PersistentPreRun(func(ctx *Context) error { return preErr }),
+			PersistentPostRun(recordPost(&calls, "teardown")),
+			Command("get", Handler(record(&calls, "handler"))),
+		)
+		_, err := cli.RunWith([]string{"cli", "get"})
+
+		assert.Equal(t, preErr, err)
+		assert.Equal(t, []string{"teardown"}, calls)
+	})
+
+	t.Run("NoHandler", func(t *testing.T) {
+		t.Parallel()
+
+		calls := []string{}
+		cli := New(PersistentPreRun(record(&calls, "pre")), Command("get"))
+		_, err := cli.RunWith([]string{"cli", "get"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, calls)
+	})
+
+	t.Run("InvalidTypes", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "Invalid type for PreRun option", func() {
+			Command("test", &options.PreRun{Handler: "invalid"})
+		})
+		assert.PanicsWithValue(t, "Invalid type for PostRun option", func() {
+			Argument("test", &options.PostRun{Handler: "invalid", Persistent: true})
+		})
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+
+	wrap := func(calls *[]string, name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx *Context) error {
+				*calls = append(*calls, name+" before")
+				err := next(ctx)
+				*calls = append(*calls, name+" after")
+				return err
+			}
+		}
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		t.Parallel()
+
+		calls := []string{}
+		cli := New(
+			Use(wrap(&calls, "first"), wrap(&calls, "second")),
+			Command("get",
+				Use(wrap(&calls, "get")),
+				PreRun(func(ctx *Context) error {
+					calls = append(calls, "pre")
+					return nil
+				}),
+				Handler(func(ctx *Context) error {
+					calls = append(calls, "handler")
+					return nil
+				}),
+			),
+		)
+		_, err := cli.RunWith([]string{"cli", "get"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"first before",
+			"second before",
+			"get before",
+			"pre",
+			"handler",
+			"get after",
+			"second after",
+			"first after",
+		}, calls)
+	})
+
+	t.Run("Recover", func(t *testing.T) {
+		t.Parallel()
+
+		recoverer := func(next HandlerFunc) HandlerFunc {
+			return func(ctx *Context) (err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("recovered: %v", r)
+					}
+				}()
+				return next(ctx)
+			}
+		}
+		cli := New(
+			Use(recoverer),
+			Command("get", Handler(func(ctx *Context) error { panic("boom") })),
+		)
+		_, err := cli.RunWith([]string{"cli", "get"})
+
+		assert.EqualError(t, err, "recovered: boom")
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithValue(t, "Invalid type for Middleware option", func() {
+			Command("test", &options.Middleware{Middleware: []interface{}{"invalid"}})
+		})
+	})
+}
@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Keys of the messages the CLI itself prints. A Catalog can translate them,
+// the values of DefaultCatalog are passed to fmt.Sprintf where noted.
+const (
+	MessageUsage                = "help.usage"
+	MessageCommands             = "help.commands"
+	MessagePlugins              = "help.plugins"
+	MessageAliases              = "help.aliases"
+	MessageOptions              = "help.options"
+	MessageExample              = "help.example"
+	MessageMoreHelp             = "help.more"              // name of the CLI
+	MessageUnknownCommand       = "error.unknown_command"  // command
+	MessageUnknownArgument      = "error.unknown_argument" // argument
+	MessageInvalidValue         = "error.invalid_value"    // name, value
+	MessageUnexpectedEnd        = "error.unexpected_end"
+	MessageExpected             = "error.expected"     // what was expected
+	MessagePrompt               = "prompt.value"       // description
+	MessagePromptDefault        = "prompt.default"     // description, default value
+	MessageConfirm              = "prompt.confirm"     // question
+	MessageConfirmYes           = "prompt.confirm_yes" // comma separated answers meaning yes
+	MessageConfirmationRequired = "error.confirmation_required"
+	MessageNotConfirmed         = "error.not_confirmed"
+	MessageScriptLine           = "script.line"    // line number
+	MessageScriptSummary        = "script.summary" // failed, total
+)
+
+// Catalog provides translated messages by key.
+type Catalog interface {
+	Message(key string) (string, bool)
+}
+
+// MapCatalog is a Catalog backed by a map from keys to messages.
+type MapCatalog map[string]string
+
+func (m MapCatalog) Message(key string) (string, bool) {
+	message, ok := m[key]
+
+	return message, ok
+}
+
+// DefaultCatalog holds the English messages used if no other catalog
+// provides a message.
+var DefaultCatalog = MapCatalog{
+	MessageUsage:                "Usage:",
+	MessageCommands:             "Commands",
+	MessagePlugins:              "Plugins",
+	MessageAliases:              "Aliases",
+	MessageOptions:              "Options",
+	MessageExample:              "Example:",
+	MessageMoreHelp:             "Use \"%s <command> --help\" for more information about a command.",
+	MessageUnknownCommand:       "unknown command: %s",
+	MessageUnknownArgument:      "unknown argument: %s",
+	MessageInvalidValue:         "invalid value for %s: %s",
+	MessageUnexpectedEnd:        "unexpected end of command",
+	MessageExpected:             "expected %s",
+	MessagePrompt:               "%s: ",
+	MessagePromptDefault:        "%s [%s]: ",
+	MessageConfirm:              "%s [y/N]: ",
+	MessageConfirmYes:           "y,yes",
+	MessageConfirmationRequired: "confirmation required, use --yes to continue",
+	MessageNotConfirmed:         "not confirmed",
+	MessageScriptLine:           "line %d:",
+	MessageScriptSummary:        "%d of %d commands failed",
+}
+
+// translator looks messages up in its catalogs in order and falls back to
+// DefaultCatalog. The zero value only uses DefaultCatalog.
+type translator struct {
+	catalogs []Catalog
+}
+
+func (t translator) lookup(key string) (string, bool) {
+	for _, catalog := range t.catalogs {
+		if message, ok := catalog.Message(key); ok {
+			return message, true
+		}
+	}
+
+	return DefaultCatalog.Message(key)
+}
+
+// message returns the translation of key formatted with args.
+func (t translator) message(key string, args ...interface{}) string {
+	message, ok := t.lookup(key)
+	if !ok {
+		message = key
+	}
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+
+	return message
+}
+
+// text translates user provided text such as descriptions. The text is
+// used as message key and returned unchanged if no catalog knows it.
+func (t translator) text(s string) string {
+	if message, ok := t.lookup(s); ok {
+		return message
+	}
+
+	return s
+}
+
+// errorMessage returns the translated message of the built-in command line
+// errors and err.Error() for any other error.
+func (t translator) errorMessage(err error) string {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) && parseErr == err {
+		err = parseErr.Err
+	}
+
+	switch v := err.(type) {
+	case UnknownCommandError:
+		return t.message(MessageUnknownCommand, string(v))
+	case UnknownArgumentError:
+		return t.message(MessageUnknownArgument, string(v))
+	case *InvalidValueError:
+		return t.message(MessageInvalidValue, v.on, v.value)
+	}
+
+	switch err {
+	case ErrUnexpectedEndCommand:
+		return t.message(MessageUnexpectedEnd)
+	case ErrConfirmationRequired:
+		return t.message(MessageConfirmationRequired)
+	case ErrNotConfirmed:
+		return t.message(MessageNotConfirmed)
+	}
+
+	return err.Error()
+}
+
+// locale returns the configured locale or the one from the environment,
+// checked in the order LC_ALL, LC_MESSAGES and LANG.
+func (c *CLI) locale() string {
+	if c.localeName != nil {
+		return *c.localeName
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := c.getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// translator returns the catalogs registered for the locale, the exact
+// locale first, e.g. "de_DE", followed by its language, e.g. "de".
+func (c *CLI) translator() translator {
+	t := translator{}
+	locale, _, _ := strings.Cut(c.locale(), ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	language, _, _ := strings.Cut(locale, "_")
+
+	if catalog, ok := c.catalogs[locale]; ok {
+		t.catalogs = append(t.catalogs, catalog)
+	}
+	if catalog, ok := c.catalogs[language]; ok && language != locale {
+		t.catalogs = append(t.catalogs, catalog)
+	}
+
+	return t
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var germanCatalog = MapCatalog{
+	MessageUsage:          "Aufruf:",
+	MessageCommands:       "Befehle",
+	MessageUnknownCommand: "unbekannter Befehl: %s",
+	"get.description":     "Eine Ressource abrufen",
+}
+
+func TestTranslator(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		tr := translator{}
+		assert.Equal(t, "Usage:", tr.message(MessageUsage))
+		assert.Equal(t, "unknown command: x", tr.message(MessageUnknownCommand, "x"))
+		assert.Equal(t, "missing.key", tr.message("missing.key"))
+		assert.Equal(t, "Some description", tr.text("Some description"))
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		t.Parallel()
+
+		tr := translator{catalogs: []Catalog{
+			MapCatalog{MessageUsage: "Aufruf (AT):"},
+			germanCatalog,
+		}}
+		assert.Equal(t, "Aufruf (AT):", tr.message(MessageUsage))
+		assert.Equal(t, "Befehle", tr.message(MessageCommands))
+		assert.Equal(t, "Options", tr.message(MessageOptions))
+		assert.Equal(t, "Eine Ressource abrufen", tr.text("get.description"))
+	})
+
+	t.Run("ErrorMessage", func(t *testing.T) {
+		t.Parallel()
+
+		tr := translator{catalogs: []Catalog{germanCatalog}}
+		assert.Equal(t, "unbekannter Befehl: x", tr.errorMessage(UnknownCommandError("x")))
+		assert.Equal(t, "unbekannter Befehl: x", tr.errorMessage(&ParseError{Err: UnknownCommandError("x")}))
+		assert.Equal(t, "invalid value for a: b", tr.errorMessage(&InvalidValueError{on: "a", value: "b"}))
+		assert.Equal(t, "unexpected end of command", tr.errorMessage(ErrUnexpectedEndCommand))
+		assert.Equal(t, "failed", tr.errorMessage(errors.New("failed")))
+	})
+}
+
+func TestCLILocale(t *testing.T) {
+	t.Run("Environment", func(t *testing.T) {
+		t.Setenv("LC_ALL", "")
+		t.Setenv("LC_MESSAGES", "de_AT.UTF-8")
+		t.Setenv("LANG", "ja_JP.UTF-8")
+
+		cli := New(Messages("de", germanCatalog), Messages("ja", MapCatalog{}))
+		assert.Equal(t, "de_AT.UTF-8", cli.locale())
+		assert.Equal(t, translator{catalogs: []Catalog{germanCatalog}}, cli.translator())
+	})
+
+	t.Run("ExactBeforeLanguage", func(t *testing.T) {
+		austrian := MapCatalog{MessageUsage: "Aufruf (AT):"}
+		cli := New(Locale("de_AT"), Messages("de", germanCatalog), Messages("de_AT", austrian))
+
+		assert.Equal(t, translator{catalogs: []Catalog{austrian, germanCatalog}}, cli.translator())
+	})
+
+	t.Run("InvalidCatalog", func(t *testing.T) {
+		assert.PanicsWithValue(t, "Invalid type for Messages option", func() {
+			New(&options.Messages{Locale: "de", Catalog: "invalid"})
+		})
+	})
+}
+
+func TestLocalizedOutput(t *testing.T) {
+	t.Parallel()
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cli := New(
+		Name("gurl"),
+		Locale("de_DE.UTF-8"),
+		Messages("de", germanCatalog),
+		Stream(stdout, stderr),
+		ExitFunc(func(int) {}),
+		Command("get", Description("get.description")),
+	)
+	cli.MustRunWith([]string{"gurl", "post"})
+
+	assert.Contains(t, stderr.String(), "unbekannter Befehl: post\n")
+	assert.Contains(t, stdout.String(), "Aufruf:\n  gurl <command>\n\nBefehle:\n  get  Eine Ressource abrufen\n")
+
+	_, err := New(Stream(io.Discard, io.Discard), Locale("de"), Messages("de", germanCatalog)).RunWith([]string{"gurl", "-h"})
+	assert.ErrorIs(t, err, ErrHelp)
+}
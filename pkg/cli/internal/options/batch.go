@@ -0,0 +1,11 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type Batch struct {
+	restriction.IsCliOption
+}
+
+type ContinueOnError struct {
+	restriction.IsCliOption
+}
@@ -0,0 +1,10 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type ExitCode struct {
+	restriction.IsCliOption
+
+	Match interface{}
+	Code  int
+}
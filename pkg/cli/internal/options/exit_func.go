@@ -0,0 +1,9 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type ExitFunc struct {
+	restriction.IsCliOption
+
+	ExitFunc interface{}
+}
@@ -0,0 +1,13 @@
+package options
+
+import (
+	"time"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+)
+
+type GracePeriod struct {
+	restriction.IsCliOption
+
+	GracePeriod time.Duration
+}
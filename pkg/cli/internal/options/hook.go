@@ -0,0 +1,21 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type PreRun struct {
+	restriction.IsCliOption
+	restriction.IsCommandOption
+	restriction.IsArgumentOption
+
+	Handler    interface{}
+	Persistent bool
+}
+
+type PostRun struct {
+	restriction.IsCliOption
+	restriction.IsCommandOption
+	restriction.IsArgumentOption
+
+	Handler    interface{}
+	Persistent bool
+}
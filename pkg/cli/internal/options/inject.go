@@ -0,0 +1,10 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type Inject struct {
+	restriction.IsCliOption
+
+	Key   interface{}
+	Value interface{}
+}
@@ -0,0 +1,9 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type Locale struct {
+	restriction.IsCliOption
+
+	Locale string
+}
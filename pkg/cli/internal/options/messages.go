@@ -0,0 +1,10 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type Messages struct {
+	restriction.IsCliOption
+
+	Locale  string
+	Catalog interface{}
+}
@@ -0,0 +1,10 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type Middleware struct {
+	restriction.IsCliOption
+	restriction.IsCommandOption
+
+	Middleware []interface{}
+}
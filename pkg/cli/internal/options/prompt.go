@@ -0,0 +1,8 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type Prompt struct {
+	restriction.IsOptionOption
+	restriction.IsArgumentOption
+}
@@ -0,0 +1,7 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type Secret struct {
+	restriction.IsOptionOption
+}
@@ -0,0 +1,15 @@
+package options
+
+import "github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+
+type ShellPrompt struct {
+	restriction.IsCliOption
+
+	Prompt string
+}
+
+type ShellHistory struct {
+	restriction.IsCliOption
+
+	Path string
+}
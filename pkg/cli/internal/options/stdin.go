@@ -0,0 +1,17 @@
+package options
+
+import (
+	"io"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/restriction"
+)
+
+type Stdin struct {
+	restriction.IsCliOption
+
+	Stdin io.Reader
+}
+
+type Interactive struct {
+	restriction.IsCliOption
+}
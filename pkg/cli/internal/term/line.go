@@ -0,0 +1,238 @@
+package term
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInterrupted is returned by LineEditor.ReadLine if Ctrl-C was pressed.
+var ErrInterrupted = errors.New("interrupted")
+
+const (
+	keyCtrlA     = 1
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyNewline   = 10
+	keyEnter     = 13
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// LineEditor reads lines from a terminal in raw mode, see MakeRaw. It
+// echoes the input itself and supports moving the cursor, browsing the
+// history with the arrow keys and completing words with tab.
+type LineEditor struct {
+	in  *bufio.Reader
+	out io.Writer
+
+	// History holds the previous lines, the most recent last.
+	History []string
+	// Complete returns the candidates for the word before the cursor,
+	// given the line up to the cursor.
+	Complete func(line string) []string
+}
+
+// NewLineEditor returns a LineEditor reading keys from in and drawing the
+// line to out.
+func NewLineEditor(in io.Reader, out io.Writer) *LineEditor {
+	return &LineEditor{in: bufio.NewReader(in), out: out}
+}
+
+// lineState is the line being edited.
+type lineState struct {
+	prompt string
+	line   []rune
+	pos    int
+}
+
+// ReadLine shows prompt and returns the entered line without the line
+// break. It returns io.EOF if Ctrl-D is pressed on an empty line and
+// ErrInterrupted if Ctrl-C is pressed.
+func (e *LineEditor) ReadLine(prompt string) (string, error) {
+	s := &lineState{prompt: prompt}
+	history := len(e.History)
+	pending := ""
+	e.refresh(s)
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			if err == io.EOF && len(s.line) > 0 {
+				io.WriteString(e.out, "\r\n")
+				return string(s.line), nil
+			}
+			return "", err
+		}
+
+		switch r {
+		case keyEnter, keyNewline:
+			io.WriteString(e.out, "\r\n")
+			return string(s.line), nil
+		case keyCtrlC:
+			io.WriteString(e.out, "^C\r\n")
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(s.line) == 0 {
+				io.WriteString(e.out, "\r\n")
+				return "", io.EOF
+			}
+			s.delete()
+		case keyBackspace, keyCtrlH:
+			if s.pos > 0 {
+				s.pos--
+				s.delete()
+			}
+		case keyCtrlA:
+			s.pos = 0
+		case keyCtrlE:
+			s.pos = len(s.line)
+		case keyCtrlU:
+			s.line = s.line[s.pos:]
+			s.pos = 0
+		case keyTab:
+			e.complete(s)
+		case keyEscape:
+			switch e.escape() {
+			case 'A':
+				if history > 0 {
+					if history == len(e.History) {
+						pending = string(s.line)
+					}
+					history--
+					s.set(e.History[history])
+				}
+			case 'B':
+				if history < len(e.History) {
+					history++
+					if history == len(e.History) {
+						s.set(pending)
+					} else {
+						s.set(e.History[history])
+					}
+				}
+			case 'C':
+				s.pos = min(s.pos+1, len(s.line))
+			case 'D':
+				s.pos = max(s.pos-1, 0)
+			case 'H':
+				s.pos = 0
+			case 'F':
+				s.pos = len(s.line)
+			case '~':
+				s.delete()
+			}
+		default:
+			if r >= ' ' {
+				s.line = append(s.line[:s.pos], append([]rune{r}, s.line[s.pos:]...)...)
+				s.pos++
+			}
+		}
+
+		e.refresh(s)
+	}
+}
+
+// escape reads the rest of an escape sequence and returns its final byte.
+// The delete key is reported as '~'.
+func (e *LineEditor) escape() rune {
+	r, _, err := e.in.ReadRune()
+	if err != nil || (r != '[' && r != 'O') {
+		return 0
+	}
+
+	r, _, err = e.in.ReadRune()
+	if err != nil {
+		return 0
+	}
+	if r >= '0' && r <= '9' {
+		digit := r
+		if r, _, err = e.in.ReadRune(); err != nil || r != '~' {
+			return 0
+		}
+		switch digit {
+		case '1', '7':
+			return 'H'
+		case '4', '8':
+			return 'F'
+		case '3':
+			return '~'
+		}
+		return 0
+	}
+
+	return r
+}
+
+// complete replaces the word before the cursor by its only candidate or
+// the longest common prefix of the candidates. If that does not extend
+// the word, the candidates are listed below the line.
+func (e *LineEditor) complete(s *lineState) {
+	if e.Complete == nil {
+		return
+	}
+
+	before := string(s.line[:s.pos])
+	start := strings.LastIndexAny(before, " \t") + 1
+	word := before[start:]
+	candidates := e.Complete(before)
+	if len(candidates) == 0 {
+		return
+	}
+
+	replacement := candidates[0]
+	if len(candidates) == 1 {
+		replacement += " "
+	} else {
+		for _, candidate := range candidates[1:] {
+			replacement = commonPrefix(replacement, candidate)
+		}
+	}
+
+	if len(replacement) <= len(word) {
+		io.WriteString(e.out, "\r\n"+strings.Join(candidates, "  ")+"\r\n")
+		return
+	}
+
+	rest := s.line[s.pos:]
+	s.line = append([]rune(before[:start]+replacement), rest...)
+	s.pos = utf8.RuneCountInString(before[:start] + replacement)
+}
+
+// refresh redraws the line and places the cursor.
+func (e *LineEditor) refresh(s *lineState) {
+	sb := strings.Builder{}
+	sb.WriteString("\r" + s.prompt + string(s.line) + "\x1b[K")
+	if back := len(s.line) - s.pos; back > 0 {
+		sb.WriteString("\x1b[" + strconv.Itoa(back) + "D")
+	}
+	io.WriteString(e.out, sb.String())
+}
+
+func (s *lineState) set(line string) {
+	s.line = []rune(line)
+	s.pos = len(s.line)
+}
+
+// delete removes the rune under the cursor.
+func (s *lineState) delete() {
+	if s.pos < len(s.line) {
+		s.line = append(s.line[:s.pos], s.line[s.pos+1:]...)
+	}
+}
+
+func commonPrefix(a, b string) string {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+
+	return a[:i]
+}
@@ -0,0 +1,110 @@
+package term
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLineEditor(t *testing.T) {
+	t.Parallel()
+
+	read := func(input string, history []string, complete func(string) []string) (string, error, string) {
+		out := &bytes.Buffer{}
+		editor := NewLineEditor(strings.NewReader(input), out)
+		editor.History = history
+		editor.Complete = complete
+		line, err := editor.ReadLine("> ")
+
+		return line, err, out.String()
+	}
+
+	t.Run("Typing", func(t *testing.T) {
+		t.Parallel()
+
+		line, err, out := read("get x\r", nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "get x", line)
+		assert.True(t, strings.HasSuffix(out, "\r> get x\x1b[K\r\n"))
+	})
+
+	t.Run("Editing", func(t *testing.T) {
+		t.Parallel()
+
+		// "gte", left, backspace, right, "t", Ctrl-A, delete, "s", Ctrl-E, "!"
+		line, err, _ := read("gte\x1b[D\x7f\x1b[Ct\x01\x1b[3~s\x05!\n", nil, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "set!", line)
+	})
+
+	t.Run("History", func(t *testing.T) {
+		t.Parallel()
+
+		history := []string{"first", "second"}
+
+		line, err, _ := read("\x1b[A\x1b[A\r", history, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "first", line)
+
+		line, err, _ = read("new\x1b[A\x1b[B\r", history, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "new", line)
+	})
+
+	t.Run("Complete", func(t *testing.T) {
+		t.Parallel()
+
+		complete := func(line string) []string {
+			switch line {
+			case "g":
+				return []string{"get"}
+			case "get --":
+				return []string{"--verbose", "--version"}
+			case "get --ver":
+				return []string{"--verbose", "--version"}
+			case "get --verb":
+				return []string{"--verbose"}
+			}
+			return nil
+		}
+
+		line, err, _ := read("g\t--\tb\t\r", nil, complete)
+		assert.NoError(t, err)
+		assert.Equal(t, "get --verbose ", line)
+
+		_, _, out := read("get --ver\t\r", nil, complete)
+		assert.Contains(t, out, "\r\n--verbose  --version\r\n")
+	})
+
+	t.Run("CtrlC", func(t *testing.T) {
+		t.Parallel()
+
+		_, err, _ := read("abc\x03", nil, nil)
+
+		assert.ErrorIs(t, err, ErrInterrupted)
+	})
+
+	t.Run("CtrlD", func(t *testing.T) {
+		t.Parallel()
+
+		_, err, _ := read("\x04", nil, nil)
+		assert.ErrorIs(t, err, io.EOF)
+
+		line, err, _ := read("ab\x01\x04\r", nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "b", line)
+	})
+}
+
+func TestMakeRaw(t *testing.T) {
+	t.Parallel()
+
+	restore, err := MakeRaw(&bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.NotPanics(t, restore)
+}
@@ -0,0 +1,51 @@
+package term
+
+import "os"
+
+// IsTerminal reports whether v is an *os.File connected to a terminal.
+func IsTerminal(v interface{}) bool {
+	file, ok := v.(*os.File)
+	if !ok || file == nil {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Width returns the column count of the terminal v is connected to.
+// It reports false if v is not a terminal or the size cannot be determined.
+func Width(v interface{}) (int, bool) {
+	if !IsTerminal(v) {
+		return 0, false
+	}
+
+	return width(v.(*os.File))
+}
+
+// DisableEcho stops the terminal v is connected to from echoing input,
+// e.g. while a password is typed. The returned function restores the
+// previous state. It does nothing if v is not a terminal.
+func DisableEcho(v interface{}) (restore func(), err error) {
+	if !IsTerminal(v) {
+		return func() {}, nil
+	}
+
+	return disableEcho(v.(*os.File))
+}
+
+// MakeRaw puts the terminal v is connected to into raw mode, so input is
+// passed on byte by byte without echo or line editing. The returned
+// function restores the previous state. It does nothing if v is not a
+// terminal.
+func MakeRaw(v interface{}) (restore func(), err error) {
+	if !IsTerminal(v) {
+		return func() {}, nil
+	}
+
+	return makeRaw(v.(*os.File))
+}
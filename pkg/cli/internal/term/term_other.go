@@ -0,0 +1,17 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package term
+
+import "os"
+
+func width(_ *os.File) (int, bool) {
+	return 0, false
+}
+
+func disableEcho(_ *os.File) (func(), error) {
+	return func() {}, nil
+}
+
+func makeRaw(_ *os.File) (func(), error) {
+	return func() {}, nil
+}
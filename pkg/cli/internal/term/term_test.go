@@ -0,0 +1,37 @@
+package term
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsTerminal(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, IsTerminal(&bytes.Buffer{}))
+	assert.False(t, IsTerminal((*os.File)(nil)))
+
+	file, err := os.CreateTemp(t.TempDir(), "term")
+	assert.NoError(t, err)
+	defer file.Close()
+	assert.False(t, IsTerminal(file))
+}
+
+func TestWidth(t *testing.T) {
+	t.Parallel()
+
+	width, ok := Width(&bytes.Buffer{})
+	assert.False(t, ok)
+	assert.Zero(t, width)
+}
+
+func TestDisableEcho(t *testing.T) {
+	t.Parallel()
+
+	restore, err := DisableEcho(&bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.NotPanics(t, restore)
+}
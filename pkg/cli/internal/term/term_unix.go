@@ -0,0 +1,82 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package term
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+type winsize struct {
+	rows    uint16
+	cols    uint16
+	xPixels uint16
+	yPixels uint16
+}
+
+func width(file *os.File) (int, bool) {
+	ws := winsize{}
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		file.Fd(),
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(&ws)),
+	)
+	if errno != 0 || ws.cols == 0 {
+		return 0, false
+	}
+
+	return int(ws.cols), true
+}
+
+func disableEcho(file *os.File) (func(), error) {
+	var state syscall.Termios
+	if err := termios(file, ioctlGetTermios, &state); err != nil {
+		return nil, err
+	}
+
+	silent := state
+	silent.Lflag &^= syscall.ECHO
+	if err := termios(file, ioctlSetTermios, &silent); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		termios(file, ioctlSetTermios, &state)
+	}, nil
+}
+
+func termios(file *os.File, request uintptr, state *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		file.Fd(),
+		request,
+		uintptr(unsafe.Pointer(state)),
+	)
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+func makeRaw(file *os.File) (func(), error) {
+	var state syscall.Termios
+	if err := termios(file, ioctlGetTermios, &state); err != nil {
+		return nil, err
+	}
+
+	raw := state
+	raw.Iflag &^= syscall.ICRNL | syscall.IXON
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termios(file, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		termios(file, ioctlSetTermios, &state)
+	}, nil
+}
@@ -0,0 +1,36 @@
+package text
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Wrap breaks s into lines of at most width runes. Line breaks in s are
+// kept, words longer than width are put on a line of their own.
+func Wrap(s string, width int) []string {
+	lines := []string{}
+
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			switch {
+			case line == "":
+				line = word
+			case width > 0 && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width:
+				lines = append(lines, line)
+				line = word
+			default:
+				line += " " + word
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+// Indent wraps s to width and prefixes every line but the first with
+// indent spaces, so the result can be placed behind a hanging label.
+func Indent(s string, width, indent int) string {
+	return strings.Join(Wrap(s, width-indent), "\n"+strings.Repeat(" ", indent))
+}
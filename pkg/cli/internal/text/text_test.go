@@ -0,0 +1,47 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ShortText", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"hello world"}, Wrap("hello world", 20))
+	})
+
+	t.Run("BreaksAtWords", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"the quick", "brown fox"}, Wrap("the quick brown fox", 10))
+	})
+
+	t.Run("LongWord", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"a", "verylongword", "b"}, Wrap("a verylongword b", 5))
+	})
+
+	t.Run("KeepsLineBreaks", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"one", "", "two"}, Wrap("one\n\ntwo", 10))
+	})
+
+	t.Run("NoWidth", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []string{"the quick brown fox"}, Wrap("the  quick brown fox", 0))
+	})
+}
+
+func TestIndent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "the quick\n    brown fox", Indent("the quick brown fox", 14, 4))
+}
@@ -28,3 +28,8 @@ func (a *AdvancedArray[T]) Back() {
 		a.index--
 	}
 }
+
+// Index returns the position of the element the next call to Next returns.
+func (a *AdvancedArray[T]) Index() int {
+	return a.index
+}
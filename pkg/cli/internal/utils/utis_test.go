@@ -82,3 +82,18 @@ func TestAdvancedArray_BackOnEmptyArray(t *testing.T) {
 	assert.False(t, ok)
 	assert.Equal(t, 0, val)
 }
+
+func TestAdvancedArray_Index(t *testing.T) {
+	t.Parallel()
+
+	advArray := NewAdvancedArray([]int{1, 2})
+	assert.Equal(t, 0, advArray.Index())
+
+	advArray.Next()
+	advArray.Next()
+	advArray.Next()
+	assert.Equal(t, 2, advArray.Index())
+
+	advArray.Back()
+	assert.Equal(t, 1, advArray.Index())
+}
@@ -0,0 +1,41 @@
+package cli
+
+// node is a read-only view of the parts the CLI, commands and arguments
+// have in common. It is used wherever the tree is walked generically.
+type node struct {
+	description *string
+	example     *string
+	argument    *argument
+	command     []*command
+	options     []*option
+}
+
+func (c *CLI) node() node {
+	return node{
+		description: c.description,
+		example:     c.example,
+		argument:    c.argument,
+		command:     c.command,
+		options:     c.options,
+	}
+}
+
+func (c *command) node() node {
+	return node{
+		description: c.description,
+		example:     c.example,
+		argument:    c.argument,
+		command:     c.command,
+		options:     c.options,
+	}
+}
+
+func (a *argument) node() node {
+	return node{
+		description: a.description,
+		example:     a.example,
+		argument:    a.argument,
+		command:     a.command,
+		options:     a.options,
+	}
+}
@@ -16,6 +16,8 @@ type option struct {
 	defaultValue *string
 	description  *string
 	validate     *regexp.Regexp
+	prompt       bool
+	secret       bool
 
 	restriction.IsCliOption
 	restriction.IsCommandOption
@@ -40,6 +42,10 @@ func Option(long string, opts ...restriction.IsOptionOption) *option {
 			o.required = true
 		case *options.Validate:
 			o.validate = v.Validate
+		case *options.Prompt:
+			o.prompt = true
+		case *options.Secret:
+			o.secret = true
 		default:
 			panic("unsupported option type")
 		}
@@ -54,14 +60,29 @@ func (o *option) call(args *utils.AdvancedArray[string], ctx *Context) error {
 			return &HelpError{on: o}
 		}
 
+		if o.secret && argValue == "--"+o.long+"-file" {
+			path, exists := args.Next()
+			if !exists {
+				return newParseError(ctx, "", args.Index(), "file for --"+o.long, ErrUnexpectedEndCommand)
+			}
+			value, err := ctx.readSecretFile(path)
+			if err != nil {
+				return err
+			}
+			if err := o.validateValue(ctx, value, args.Index()-1); err != nil {
+				return err
+			}
+			ctx.options[o.long] = value
+			ctx.secrets[o.long] = true
+
+			return nil
+		}
+
 		if argValue == "--"+o.long || o.short != nil && argValue == "-"+string(*o.short) {
 			argValue, exists := args.Next()
 			if exists && !strings.HasPrefix(argValue, "-") {
-				if o.validate != nil && !o.validate.MatchString(argValue) {
-					return &InvalidValueError{
-						on:    o.long,
-						value: argValue,
-					}
+				if err := o.validateValue(ctx, argValue, args.Index()-1); err != nil {
+					return err
 				}
 				ctx.options[o.long] = argValue
 			} else {
@@ -70,6 +91,9 @@ func (o *option) call(args *utils.AdvancedArray[string], ctx *Context) error {
 				}
 				ctx.options[o.long] = ""
 			}
+			if o.secret {
+				ctx.secrets[o.long] = true
+			}
 
 			return nil
 		}
@@ -79,3 +103,42 @@ func (o *option) call(args *utils.AdvancedArray[string], ctx *Context) error {
 
 	return ErrNotMatched
 }
+
+// callOptions matches opts against the arguments at the current position
+// of args, in any order, until none of them matches.
+func callOptions(opts []*option, args *utils.AdvancedArray[string], ctx *Context) error {
+	for matched := true; matched; {
+		matched = false
+		for _, opt := range opts {
+			if err := opt.call(args, ctx); err == nil {
+				matched = true
+			} else if err != ErrNotMatched {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateValue checks value against the Validate pattern of the option.
+// The value of a secret option is redacted in the returned error.
+func (o *option) validateValue(ctx *Context, value string, index int) error {
+	if o.validate == nil || o.validate.MatchString(value) {
+		return nil
+	}
+
+	token := value
+	if o.secret {
+		token = redacted
+	}
+	err := newParseError(ctx, token, index, "value for --"+o.long, &InvalidValueError{
+		on:    o.long,
+		value: token,
+	})
+	if o.secret {
+		err.secrets = append(err.secrets, value)
+	}
+
+	return err
+}
@@ -137,7 +137,7 @@ func TestOptionCall(t *testing.T) {
 		assert.Equal(t, &InvalidValueError{
 			on:    "test",
 			value: "invalid",
-		}, err)
+		}, parseErrorCause(t, err))
 	})
 
 	t.Run("WithHelpOption", func(t *testing.T) {
@@ -151,3 +151,17 @@ func TestOptionCall(t *testing.T) {
 		assert.IsType(t, &HelpError{}, err)
 	})
 }
+
+func TestCallOptions(t *testing.T) {
+	t.Parallel()
+
+	args := utils.NewAdvancedArray([]string{"--b", "2", "--a", "1", "other"})
+	ctx := &Context{options: make(map[string]string)}
+
+	err := callOptions([]*option{Option("a"), Option("b")}, args, ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, ctx.options)
+	next, _ := args.Next()
+	assert.Equal(t, "other", next)
+}
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"errors"
 	"io"
+	"reflect"
 	"regexp"
+	"time"
 
 	"github.com/StevenCyb/GoCLI/pkg/cli/internal/options"
 )
@@ -52,6 +55,9 @@ func Description(description string) *options.Description {
 
 type HandlerFunc func(ctx *Context) error
 
+// Middleware wraps a handler, e.g. to measure, recover or authorize it.
+type Middleware func(next HandlerFunc) HandlerFunc
+
 // CLI, Command, argument
 func Handler(fn HandlerFunc) *options.Handler {
 	return &options.Handler{
@@ -59,6 +65,35 @@ func Handler(fn HandlerFunc) *options.Handler {
 	}
 }
 
+// CLI, Command, argument
+// HandlerOf creates a Handler option for fn, which receives its input as a
+// struct of type T. Fields tagged with `cli:"name"` are set to the argument
+// or option of that name, converted to the field type. Supported are
+// strings, booleans, integers, floats, time.Duration, types implementing
+// encoding.TextUnmarshaler and pointers to those, which stay nil if the
+// argument or option was not given. A value that can not be converted fails
+// the run with an *InvalidValueError.
+//
+//	type getInput struct {
+//		URL     string        `cli:"url"`
+//		Verbose bool          `cli:"verbose"`
+//		Timeout time.Duration `cli:"timeout"`
+//	}
+//
+//	cli.Command("get", cli.Argument("url", cli.HandlerOf(get)))
+func HandlerOf[T any](fn func(ctx *Context, in T) error) *options.Handler {
+	checkInput(reflect.TypeOf((*T)(nil)).Elem())
+
+	return Handler(func(ctx *Context) error {
+		var in T
+		if err := ctx.bind(reflect.ValueOf(&in).Elem()); err != nil {
+			return err
+		}
+
+		return fn(ctx, in)
+	})
+}
+
 // Option
 func Required() *options.Required {
 	return &options.Required{}
@@ -84,3 +119,282 @@ func Validate(reg *regexp.Regexp) *options.Validate {
 		Validate: reg,
 	}
 }
+
+// CLI
+func Renderer(renderer HelpRenderer) *options.HelpRenderer {
+	return &options.HelpRenderer{
+		Renderer: renderer,
+	}
+}
+
+// CLI
+// HelpTemplate renders the help with the given text/template, see
+// NewTemplateHelpRenderer. It panics if the template cannot be parsed.
+func HelpTemplate(text string) *options.HelpRenderer {
+	renderer, err := NewTemplateHelpRenderer(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return &options.HelpRenderer{
+		Renderer: renderer,
+	}
+}
+
+// CLI
+// Colors styles help and error output with the given theme. It also adds a
+// global --color=auto|always|never flag.
+func Colors(theme Theme) *options.Colors {
+	return &options.Colors{
+		Theme: theme,
+	}
+}
+
+// CLI
+// ExitFunc replaces os.Exit for MustRun, MustRunWith and forced exits
+// after an interrupt.
+func ExitFunc(exit func(code int)) *options.ExitFunc {
+	return &options.ExitFunc{
+		ExitFunc: exit,
+	}
+}
+
+// CLI
+// Getenv replaces os.Getenv for the environment variables the CLI reads,
+// e.g. NO_COLOR, COLUMNS, LANG or PATH.
+func Getenv(getenv func(key string) string) *options.Getenv {
+	return &options.Getenv{
+		Getenv: getenv,
+	}
+}
+
+// Command
+// Confirm asks the yes/no question message on the terminal before the
+// handlers below the command run, e.g. "This will delete %s. Continue?".
+// Verbs in message are replaced by the values of the arguments matched
+// below the command. An option --yes, -y is added to skip the question,
+// without -y where another option already uses it. Without a terminal, or
+// with --no-input, the run fails with ErrConfirmationRequired unless --yes
+// is given.
+func Confirm(message string) *options.Confirm {
+	return &options.Confirm{
+		Message: message,
+	}
+}
+
+// Option
+// Secret marks the value of the option as sensitive. It is prompted for
+// without echo, its default is not shown in help and it is redacted in
+// errors and Context.String. The value can also be read from a file with
+// --<name>-file <path>, or from stdin with --<name>-file -.
+func Secret() *options.Secret {
+	return &options.Secret{}
+}
+
+// CLI
+// Stdin replaces os.Stdin as the source of prompted values.
+func Stdin(stdin io.Reader) *options.Stdin {
+	return &options.Stdin{
+		Stdin: stdin,
+	}
+}
+
+// CLI
+// Interactive asks for prompts and confirmations even if stdin is not a
+// terminal, e.g. to answer them from Stdin in tests. --no-input still
+// turns them off.
+func Interactive() *options.Interactive {
+	return &options.Interactive{}
+}
+
+// Option, Argument
+// Prompt asks for the value of the argument or option on the terminal if
+// it is missing from the command line, using its description as the text
+// and asking again until it passes Validate. Prompting is skipped if stdin
+// is not a terminal, see Interactive, or --no-input is given.
+func Prompt() *options.Prompt {
+	return &options.Prompt{}
+}
+
+// CLI
+// Output adds a global --output option selecting the format Context.Output
+// writes in: OutputJSON, OutputYAML, OutputTable, OutputCSV or
+// "template=" followed by a text/template. format is used if --output is
+// not given.
+func Output(format string) *options.Output {
+	return &options.Output{
+		Format: format,
+	}
+}
+
+// CLI
+// ShellPrompt sets the prompt of RunShell, "<name>> " by default.
+func ShellPrompt(prompt string) *options.ShellPrompt {
+	return &options.ShellPrompt{
+		Prompt: prompt,
+	}
+}
+
+// CLI
+// ShellHistory keeps the lines entered in RunShell in the file at path, so
+// they can be recalled in later sessions.
+func ShellHistory(path string) *options.ShellHistory {
+	return &options.ShellHistory{
+		Path: path,
+	}
+}
+
+// CLI
+// Plugins runs an unknown command as the executable "<name>-<command>"
+// found in one of dirs or on the PATH, with the remaining arguments and
+// the streams of the CLI. Its exit code is passed through as *PluginError.
+// The discovered plugins are listed in the help.
+func Plugins(dirs ...string) *options.Plugins {
+	return &options.Plugins{
+		Dirs: dirs,
+	}
+}
+
+// CLI
+// Aliases lets users define aliases for command lines in the file at path,
+// e.g. co = "checkout --force". An alias is expanded if it is the first
+// argument, $1, $2... in the expansion are replaced by the arguments
+// following it. Aliases named like a command are ignored. An "alias"
+// command with the subcommands set, list and remove manages the file, and
+// the aliases are listed in the help.
+func Aliases(path string) *options.Aliases {
+	return &options.Aliases{
+		Path: path,
+	}
+}
+
+// CLI
+// ResponseFiles replaces every argument "@path" with the arguments in the
+// file at path before parsing, split like SplitArgs does. Response files
+// may include others. "@@x" is passed on as "@x".
+func ResponseFiles() *options.ResponseFiles {
+	return &options.ResponseFiles{}
+}
+
+// CLI
+// Batch adds a global --batch <file> option that runs the commands in the
+// file with RunScript, or the commands read from stdin for "-".
+func Batch() *options.Batch {
+	return &options.Batch{}
+}
+
+// CLI
+// ContinueOnError makes RunScript run all commands even if some fail and
+// summarize the failures at the end.
+func ContinueOnError() *options.ContinueOnError {
+	return &options.ContinueOnError{}
+}
+
+// CLI
+// Inject makes value available to all hooks, middleware and handlers
+// through Context.Value, e.g. a shared client or logger.
+func Inject(key, value interface{}) *options.Inject {
+	return &options.Inject{
+		Key:   key,
+		Value: value,
+	}
+}
+
+// CLI
+// GracePeriod limits the time the handler has to return after the first
+// SIGINT or SIGTERM cancelled its context. Without a grace period, only a
+// second signal forces the CLI to exit.
+func GracePeriod(d time.Duration) *options.GracePeriod {
+	return &options.GracePeriod{
+		GracePeriod: d,
+	}
+}
+
+// CLI
+// VersionCommand adds a "version" command that prints the name and version
+// of the CLI. Its --build option adds build metadata, --json prints JSON.
+func VersionCommand() *options.VersionCommand {
+	return &options.VersionCommand{}
+}
+
+// CLI
+// ExitCodeFor makes MustRun exit with code if the returned error contains
+// an error of type E, as determined by errors.As.
+func ExitCodeFor[E error](code int) *options.ExitCode {
+	return &options.ExitCode{
+		Match: func(err error) bool {
+			var target E
+			return errors.As(err, &target)
+		},
+		Code: code,
+	}
+}
+
+// CLI
+// Messages registers the catalog for a locale, e.g. "de" or "ja_JP".
+// Descriptions and examples are looked up in the catalog as keys, too.
+func Messages(locale string, catalog Catalog) *options.Messages {
+	return &options.Messages{
+		Locale:  locale,
+		Catalog: catalog,
+	}
+}
+
+// CLI
+// Locale selects the locale instead of LC_ALL, LC_MESSAGES and LANG.
+func Locale(locale string) *options.Locale {
+	return &options.Locale{
+		Locale: locale,
+	}
+}
+
+// CLI, Command, argument
+// PreRun is called before the handler of the CLI, command or argument it
+// is given to.
+func PreRun(fn HandlerFunc) *options.PreRun {
+	return &options.PreRun{
+		Handler: fn,
+	}
+}
+
+// CLI, Command, argument
+// PostRun is called after the handler of the CLI, command or argument it
+// is given to, with the error the handler returned.
+func PostRun(fn PostRunFunc) *options.PostRun {
+	return &options.PostRun{
+		Handler: fn,
+	}
+}
+
+// CLI, Command, argument
+// PersistentPreRun is like PreRun but also runs before the handlers of all
+// descendants, starting with the hook closest to the root.
+func PersistentPreRun(fn HandlerFunc) *options.PreRun {
+	return &options.PreRun{
+		Handler:    fn,
+		Persistent: true,
+	}
+}
+
+// CLI, Command, argument
+// PersistentPostRun is like PostRun but also runs after the handlers of all
+// descendants, starting with the hook closest to the leaf.
+func PersistentPostRun(fn PostRunFunc) *options.PostRun {
+	return &options.PostRun{
+		Handler:    fn,
+		Persistent: true,
+	}
+}
+
+// CLI, Command
+// Use wraps the handlers of the CLI or command and all of its descendants
+// with the given middleware. Middleware of the CLI wraps the middleware of
+// its commands, and the first middleware given is the outermost one.
+func Use(middleware ...Middleware) *options.Middleware {
+	o := &options.Middleware{}
+	for _, mw := range middleware {
+		o.Middleware = append(o.Middleware, mw)
+	}
+
+	return o
+}
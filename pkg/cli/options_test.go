@@ -3,7 +3,9 @@ package cli
 import (
 	"io"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -117,3 +119,217 @@ func TestValidate(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Equal(t, regex, result.Validate)
 }
+
+func TestRenderer(t *testing.T) {
+	t.Parallel()
+
+	result := Renderer(DefaultHelpRenderer{})
+
+	assert.NotNil(t, result)
+	assert.Equal(t, DefaultHelpRenderer{}, result.Renderer)
+}
+
+func TestHelpTemplate(t *testing.T) {
+	t.Parallel()
+
+	result := HelpTemplate("{{.Name}}")
+
+	assert.NotNil(t, result)
+	assert.IsType(t, &TemplateHelpRenderer{}, result.Renderer)
+	assert.Panics(t, func() {
+		HelpTemplate("{{")
+	})
+}
+
+func TestVersionCommand(t *testing.T) {
+	t.Parallel()
+
+	result := VersionCommand()
+
+	assert.NotNil(t, result)
+}
+
+func TestExitCodeFor(t *testing.T) {
+	t.Parallel()
+
+	result := ExitCodeFor[*InvalidValueError](3)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, 3, result.Code)
+	assert.True(t, result.Match.(func(error) bool)(&InvalidValueError{}))
+	assert.False(t, result.Match.(func(error) bool)(ErrNotMatched))
+}
+
+func TestMessages(t *testing.T) {
+	t.Parallel()
+
+	catalog := MapCatalog{MessageUsage: "Aufruf:"}
+	result := Messages("de", catalog)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "de", result.Locale)
+	assert.Equal(t, catalog, result.Catalog)
+}
+
+func TestLocale(t *testing.T) {
+	t.Parallel()
+
+	result := Locale("ja_JP")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "ja_JP", result.Locale)
+}
+
+func TestPreRun(t *testing.T) {
+	t.Parallel()
+
+	result := PreRun(func(ctx *Context) error { return nil })
+	persistent := PersistentPreRun(func(ctx *Context) error { return nil })
+
+	assert.NotNil(t, result.Handler)
+	assert.False(t, result.Persistent)
+	assert.True(t, persistent.Persistent)
+}
+
+func TestPostRun(t *testing.T) {
+	t.Parallel()
+
+	result := PostRun(func(ctx *Context, err error) error { return err })
+	persistent := PersistentPostRun(func(ctx *Context, err error) error { return err })
+
+	assert.NotNil(t, result.Handler)
+	assert.False(t, result.Persistent)
+	assert.True(t, persistent.Persistent)
+}
+
+func TestUse(t *testing.T) {
+	t.Parallel()
+
+	middleware := func(next HandlerFunc) HandlerFunc { return next }
+	result := Use(middleware, middleware)
+
+	assert.NotNil(t, result)
+	assert.Len(t, result.Middleware, 2)
+}
+
+func TestGracePeriod(t *testing.T) {
+	t.Parallel()
+
+	result := GracePeriod(5 * time.Second)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, 5*time.Second, result.GracePeriod)
+}
+
+func TestInject(t *testing.T) {
+	t.Parallel()
+
+	result := Inject("key", "value")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "key", result.Key)
+	assert.Equal(t, "value", result.Value)
+}
+
+func TestOutputOption(t *testing.T) {
+	t.Parallel()
+
+	result := Output(OutputYAML)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, OutputYAML, result.Format)
+}
+
+func TestStdin(t *testing.T) {
+	t.Parallel()
+
+	stdin := strings.NewReader("input")
+	result := Stdin(stdin)
+
+	assert.NotNil(t, result)
+	assert.Equal(t, stdin, result.Stdin)
+}
+
+func TestPrompt(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, Prompt())
+}
+
+func TestSecret(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, Secret())
+}
+
+func TestConfirmOption(t *testing.T) {
+	t.Parallel()
+
+	result := Confirm("Continue?")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "Continue?", result.Message)
+}
+
+func TestShellPrompt(t *testing.T) {
+	t.Parallel()
+
+	result := ShellPrompt("$ ")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "$ ", result.Prompt)
+}
+
+func TestShellHistory(t *testing.T) {
+	t.Parallel()
+
+	result := ShellHistory("/tmp/history")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "/tmp/history", result.Path)
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, Batch())
+}
+
+func TestContinueOnError(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, ContinueOnError())
+}
+
+func TestPluginsOption(t *testing.T) {
+	t.Parallel()
+
+	result := Plugins("/usr/lib/gurl")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, []string{"/usr/lib/gurl"}, result.Dirs)
+}
+
+func TestResponseFilesOption(t *testing.T) {
+	t.Parallel()
+
+	assert.NotNil(t, ResponseFiles())
+}
+
+func TestAliasesOption(t *testing.T) {
+	t.Parallel()
+
+	result := Aliases("/home/ada/.config/git/aliases")
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "/home/ada/.config/git/aliases", result.Path)
+}
+
+func TestGetenv(t *testing.T) {
+	t.Parallel()
+
+	result := Getenv(func(key string) string { return key })
+
+	assert.NotNil(t, result)
+	assert.Equal(t, "KEY", result.Getenv.(func(key string) string)("KEY"))
+}
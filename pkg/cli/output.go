@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats selectable with --output, see Output.
+const (
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	OutputTable = "table"
+	OutputCSV   = "csv"
+	// OutputTemplate is followed by a text/template executed with the
+	// value, e.g. "--output template={{.Name}}".
+	OutputTemplate = "template"
+)
+
+// outputFormat writes v to w in one of the output formats.
+type outputFormat func(w io.Writer, v interface{}) error
+
+// parseOutputFormat returns the outputFormat for the value of --output.
+func parseOutputFormat(format string) (outputFormat, error) {
+	if content, ok := strings.CutPrefix(format, OutputTemplate+"="); ok {
+		tmpl, err := template.New("output").Parse(content)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(w io.Writer, v interface{}) error {
+			return tmpl.Execute(w, v)
+		}, nil
+	}
+
+	switch format {
+	case OutputJSON:
+		return writeJSON, nil
+	case OutputYAML:
+		return writeYAML, nil
+	case OutputTable:
+		return writeTable, nil
+	case OutputCSV:
+		return writeCSV, nil
+	}
+
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
+
+// parseOutputFlag removes --output from args and returns the selected
+// output format. Without --output, fallback or, if it is nil, the format
+// given to the Output option is used.
+func (c *CLI) parseOutputFlag(args *flagArgs, fallback outputFormat) (outputFormat, error) {
+	value, index, found := args.extractFlag("output")
+	if !found && fallback != nil {
+		return fallback, nil
+	} else if !found {
+		value = *c.outputDefault
+	}
+
+	format, err := parseOutputFormat(value)
+	if err != nil {
+		return nil, &ParseError{
+			Token:    value,
+			Index:    index,
+			Expected: "json, yaml, table, csv or template=<template>",
+			Err:      &InvalidValueError{on: "output", value: value},
+		}
+	}
+
+	return format, nil
+}
+
+// Output writes v to stdout in the format selected with --output, see the
+// Output option. Without that option, v is written as JSON.
+func (c *Context) Output(v interface{}) error {
+	w := c.stdout
+	if w == nil {
+		w = os.Stdout
+	}
+
+	format := c.output
+	if format == nil {
+		format = writeJSON
+	}
+
+	return format(w, v)
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+func writeYAML(w io.Writer, v interface{}) error {
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+// writeTable writes v as columns aligned with spaces, see tabulate.
+func writeTable(w io.Writer, v interface{}) error {
+	header, rows := tabulate(v)
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+
+	if header != nil {
+		upper := make([]string, len(header))
+		for i, name := range header {
+			upper[i] = strings.ToUpper(name)
+		}
+		rows = append([][]string{upper}, rows...)
+	}
+	for _, row := range rows {
+		if _, err := io.WriteString(tw, strings.Join(row, "\t")+"\n"); err != nil {
+			return err
+		}
+	}
+
+	return tw.Flush()
+}
+
+// writeCSV writes v as comma separated values, see tabulate.
+func writeCSV(w io.Writer, v interface{}) error {
+	header, rows := tabulate(v)
+	cw := csv.NewWriter(w)
+
+	if header != nil {
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return cw.Error()
+}
+
+// tabulate turns v into rows. A slice or array has a row per element,
+// anything else is a single row. Structs have a column per exported field,
+// named by its json tag if present, and maps a column per key. Other
+// values are a single column without header.
+func tabulate(v interface{}) ([]string, [][]string) {
+	value := indirect(reflect.ValueOf(v))
+	if !value.IsValid() {
+		return nil, nil
+	}
+
+	items := []reflect.Value{value}
+	elem := value.Type()
+	if value.Kind() == reflect.Slice || value.Kind() == reflect.Array {
+		items = nil
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, indirect(value.Index(i)))
+		}
+		elem = elem.Elem()
+		for elem.Kind() == reflect.Pointer {
+			elem = elem.Elem()
+		}
+	}
+
+	var header []string
+	var cells func(item reflect.Value) []string
+
+	switch {
+	case elem.Kind() == reflect.Struct:
+		var fields []int
+		for i := 0; i < elem.NumField(); i++ {
+			field := elem.Field(i)
+			name := field.Name
+			if tag, ok := field.Tag.Lookup("json"); ok {
+				tag, _, _ = strings.Cut(tag, ",")
+				if tag == "-" {
+					continue
+				}
+				if tag != "" {
+					name = tag
+				}
+			}
+			if field.IsExported() {
+				header = append(header, name)
+				fields = append(fields, i)
+			}
+		}
+		cells = func(item reflect.Value) []string {
+			row := make([]string, len(fields))
+			for i, field := range fields {
+				row[i] = cell(item.Field(field))
+			}
+			return row
+		}
+	case elem.Kind() == reflect.Map:
+		keys := map[string]bool{}
+		for _, item := range items {
+			for _, key := range item.MapKeys() {
+				keys[fmt.Sprint(key.Interface())] = true
+			}
+		}
+		for key := range keys {
+			header = append(header, key)
+		}
+		sort.Strings(header)
+		cells = func(item reflect.Value) []string {
+			values := map[string]string{}
+			iter := item.MapRange()
+			for iter.Next() {
+				values[fmt.Sprint(iter.Key().Interface())] = cell(iter.Value())
+			}
+			row := make([]string, len(header))
+			for i, key := range header {
+				row[i] = values[key]
+			}
+			return row
+		}
+	default:
+		cells = func(item reflect.Value) []string {
+			return []string{cell(item)}
+		}
+	}
+
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		if !item.IsValid() {
+			continue
+		}
+		rows = append(rows, cells(item))
+	}
+
+	return header, rows
+}
+
+// indirect dereferences pointers and interfaces until it reaches a value.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// cell formats v for a table or CSV, nil pointers are empty.
+func cell(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+
+	return fmt.Sprint(v.Interface())
+}
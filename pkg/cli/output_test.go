@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type outputUser struct {
+	Name   string  `json:"name" yaml:"name"`
+	Age    int     `json:"age" yaml:"age"`
+	Email  *string `json:"email,omitempty" yaml:"email,omitempty"`
+	secret string
+}
+
+func runOutput(t *testing.T, format string, args []string, v interface{}) (string, error) {
+	t.Helper()
+
+	stdout := &bytes.Buffer{}
+	cli := New(
+		Stream(stdout, &bytes.Buffer{}),
+		Output(format),
+		Handler(func(ctx *Context) error { return ctx.Output(v) }),
+	)
+	_, err := cli.RunWith(append([]string{"cli"}, args...))
+
+	return stdout.String(), err
+}
+
+func TestOutput(t *testing.T) {
+	t.Parallel()
+
+	email := "ada@example.com"
+	users := []outputUser{{Name: "Ada", Age: 36, Email: &email}, {Name: "Bob", Age: 7}}
+
+	t.Run("Table", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runOutput(t, OutputTable, nil, users)
+
+		require.NoError(t, err)
+		assert.Equal(t, "NAME   AGE   EMAIL\nAda    36    ada@example.com\nBob    7     \n", out)
+	})
+
+	t.Run("TableOfMap", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runOutput(t, OutputTable, nil, map[string]int{"b": 2, "a": 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "A   B\n1   2\n", out)
+	})
+
+	t.Run("TableOfScalars", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runOutput(t, OutputTable, nil, []string{"a", "b"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "a\nb\n", out)
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runOutput(t, OutputCSV, nil, users)
+
+		require.NoError(t, err)
+		assert.Equal(t, "name,age,email\nAda,36,ada@example.com\nBob,7,\n", out)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runOutput(t, OutputTable, []string{"--output", "json"}, users[1])
+
+		require.NoError(t, err)
+		assert.Equal(t, "{\n  \"name\": \"Bob\",\n  \"age\": 7\n}\n", out)
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runOutput(t, OutputTable, []string{"--output=yaml"}, users[1])
+
+		require.NoError(t, err)
+		assert.Equal(t, "name: Bob\nage: 7\n", out)
+	})
+
+	t.Run("Template", func(t *testing.T) {
+		t.Parallel()
+
+		out, err := runOutput(t, OutputTable, []string{"--output", "template={{range .}}{{.Name}};{{end}}"}, users)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Ada;Bob;", out)
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := runOutput(t, OutputTable, []string{"--output", "xml"}, users)
+
+		var parseErr *ParseError
+		require.True(t, errors.As(err, &parseErr))
+		assert.Equal(t, 2, parseErr.Index)
+		assert.Equal(t, "xml", parseErr.Token)
+		assert.Equal(t, "json, yaml, table, csv or template=<template>", parseErr.Expected)
+	})
+
+	t.Run("InvalidFormatAfterColor", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Stream(io.Discard, io.Discard), Colors(DefaultTheme), Output(OutputTable))
+		_, err := cli.RunWith([]string{"cli", "--color=never", "--output", "xml"})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 3, parseErr.Index)
+
+		_, err = cli.RunWith([]string{"cli", "--output=xml", "--color", "never"})
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 1, parseErr.Index)
+	})
+
+	t.Run("ArgumentEqualToFormat", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Stream(io.Discard, io.Discard), Output(OutputTable), Command("get", Handler(func(ctx *Context) error {
+			return nil
+		})))
+		_, err := cli.RunWith([]string{"cli", "--output", "json", "json"})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "json", parseErr.Token)
+		assert.Equal(t, 3, parseErr.Index)
+	})
+
+	t.Run("ShadowedByCommandOption", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(
+			Stream(stdout, io.Discard),
+			Output(OutputJSON),
+			Command("get",
+				Option("output"),
+				Handler(func(ctx *Context) error { return ctx.Output(map[string]int{"a": 1}) }),
+			),
+		)
+
+		ctx, err := cli.RunWith([]string{"cli", "get", "--output", "file.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "file.txt", *ctx.GetOption("output"))
+		assert.Equal(t, "{\n  \"a\": 1\n}\n", stdout.String())
+
+		stdout.Reset()
+		ctx, err = cli.RunWith([]string{"cli", "--output", "yaml", "get", "--output", "file.txt"})
+		require.NoError(t, err)
+		assert.Equal(t, "file.txt", *ctx.GetOption("output"))
+		assert.Equal(t, "a: 1\n", stdout.String())
+	})
+
+	t.Run("InvalidDefault", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Panics(t, func() {
+			New(Output("xml"))
+		})
+	})
+
+	t.Run("WithoutOption", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(
+			Stream(stdout, &bytes.Buffer{}),
+			Option("output"),
+			Handler(func(ctx *Context) error { return ctx.Output([]int{1, 2}) }),
+		)
+		ctx, err := cli.RunWith([]string{"cli", "--output", "table"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "table", *ctx.GetOption("output"))
+		assert.Equal(t, "[\n  1,\n  2\n]\n", stdout.String())
+	})
+}
+
+func TestOutputConcurrentRuns(t *testing.T) {
+	t.Parallel()
+
+	cli := New(
+		Stream(io.Discard, io.Discard),
+		Colors(DefaultTheme),
+		Output(OutputJSON),
+		Command("show", Handler(func(ctx *Context) error { return nil })),
+	)
+
+	var wg sync.WaitGroup
+	for _, format := range []string{"json", "yaml", "json", "yaml"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, err := cli.RunLine("show --color=never --output " + format)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			buf := &bytes.Buffer{}
+			assert.NoError(t, ctx.output(buf, map[string]int{"a": 1}))
+			if format == "yaml" {
+				assert.Equal(t, "a: 1\n", buf.String())
+			} else {
+				assert.Equal(t, "{\n  \"a\": 1\n}\n", buf.String())
+			}
+		}()
+	}
+	wg.Wait()
+}
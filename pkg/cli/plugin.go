@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PluginError is returned if a plugin exited with a non-zero status. Its
+// exit code is passed through by MustRun.
+type PluginError struct {
+	// Name is the command the plugin was run for.
+	Name string
+	Code int
+	Err  error
+}
+
+func (e *PluginError) Error() string {
+	return "plugin " + e.Name + " exited with status " + strconv.Itoa(e.Code)
+}
+
+func (e *PluginError) Unwrap() error {
+	return e.Err
+}
+
+func (e *PluginError) ExitCode() int {
+	if e.Code < 0 {
+		return ExitFailure
+	}
+
+	return e.Code
+}
+
+// pluginPrefix is the prefix of the executables of the plugins.
+func (c *CLI) pluginPrefix() string {
+	return c.Name() + "-"
+}
+
+// pluginSearchPath returns the plugin directories followed by the PATH.
+func (c *CLI) pluginSearchPath() []string {
+	return append(append([]string{}, c.pluginDirs...), filepath.SplitList(c.getenv("PATH"))...)
+}
+
+// lookupPlugin returns the path of the executable for the command name.
+func (c *CLI) lookupPlugin(name string) (string, bool) {
+	if name == "" || strings.HasPrefix(name, "-") || strings.ContainsAny(name, `/\`) || c.hasCommand(name) {
+		return "", false
+	}
+
+	for _, dir := range c.pluginSearchPath() {
+		if dir == "" {
+			continue
+		}
+		path, err := exec.LookPath(filepath.Join(dir, c.pluginPrefix()+name))
+		if err == nil {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// discoverPlugins returns the names of the commands provided by plugins, sorted
+// and without those shadowed by commands of the CLI.
+func (c *CLI) discoverPlugins() []string {
+	seen := map[string]bool{}
+	for _, dir := range c.pluginSearchPath() {
+		entries, err := os.ReadDir(dir)
+		if dir == "" || err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), c.pluginPrefix())
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if !ok || name == "" || entry.IsDir() || c.hasCommand(name) {
+				continue
+			}
+			if _, found := c.lookupPlugin(name); found {
+				seen[name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// runPlugin runs the plugin at path with args and the streams of the CLI.
+func (c *CLI) runPlugin(name, path string, args []string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = c.stdin
+	cmd.Stdout = c.stdout
+	cmd.Stderr = c.stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &PluginError{Name: name, Code: exitErr.ExitCode(), Err: err}
+	}
+
+	return err
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writePlugin(t *testing.T, dir, name, script string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0o755))
+}
+
+func TestPlugins(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts")
+	}
+
+	dir := t.TempDir()
+	writePlugin(t, dir, "gurl-hello", `echo "hello $*"; read name; echo "hi $name" >&2`)
+	writePlugin(t, dir, "gurl-fail", "exit 3")
+	writePlugin(t, dir, "gurl-get", "echo shadowed")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gurl-notexec"), []byte("x"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other-tool"), []byte("x"), 0o755))
+
+	newCLI := func(stdin string, stdout, stderr *bytes.Buffer) *CLI {
+		return New(
+			Name("gurl"),
+			Stdin(bytes.NewBufferString(stdin)),
+			Stream(stdout, stderr),
+			Plugins(dir),
+			Command("get", Handler(func(ctx *Context) error {
+				stdout.WriteString("builtin get\n")
+				return nil
+			})),
+		)
+	}
+
+	t.Run("Run", func(t *testing.T) {
+		t.Parallel()
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		ctx, err := newCLI("ada\n", stdout, stderr).RunWith([]string{"gurl", "hello", "a", "--b"})
+
+		require.NoError(t, err)
+		assert.True(t, ctx.VisitedCommand("hello"))
+		assert.Equal(t, "hello a --b\n", stdout.String())
+		assert.Equal(t, "hi ada\n", stderr.String())
+	})
+
+	t.Run("ExitCode", func(t *testing.T) {
+		t.Parallel()
+
+		code := -1
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cli := newCLI("", stdout, stderr)
+		cli.exit = func(c int) { code = c }
+		cli.MustRunWith([]string{"gurl", "fail"})
+
+		assert.Equal(t, 3, code)
+		assert.Empty(t, stdout.String())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("Shadowed", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		_, err := newCLI("", stdout, &bytes.Buffer{}).RunWith([]string{"gurl", "get"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "builtin get\n", stdout.String())
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		t.Parallel()
+
+		for _, name := range []string{"notexec", "missing", "../gurl-hello"} {
+			_, err := newCLI("", &bytes.Buffer{}, &bytes.Buffer{}).RunWith([]string{"gurl", name})
+			assert.ErrorIs(t, err, UnknownCommandError(name))
+		}
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := newCLI("", stdout, &bytes.Buffer{})
+		cli.PrintHelp()
+
+		assert.Contains(t, stdout.String(), "Plugins:\n  fail\n  hello\n")
+		assert.Equal(t, []string{"get", "hello"}, append(cli.complete("ge"), cli.complete("he")...))
+	})
+}
+
+func TestPluginError(t *testing.T) {
+	t.Parallel()
+
+	err := &PluginError{Name: "deploy", Code: 4}
+
+	assert.EqualError(t, err, "plugin deploy exited with status 4")
+	assert.Equal(t, 4, err.ExitCode())
+	assert.Equal(t, ExitFailure, (&PluginError{Code: -1}).ExitCode())
+}
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/term"
+)
+
+// prompter asks for values missing from the command line. It is only set
+// on the Context if stdin is a terminal and --no-input was not given.
+type prompter struct {
+	stdin      io.Reader
+	in         *bufio.Reader
+	out        io.Writer
+	translator translator
+}
+
+// newPrompter returns a prompter reading from the stdin of the CLI, or nil
+// if prompting is disabled.
+func (c *CLI) newPrompter(noInput bool) *prompter {
+	if noInput || !c.interactive && !c.isTerminal(c.stdin) {
+		return nil
+	}
+
+	return &prompter{
+		stdin:      c.stdin,
+		in:         bufio.NewReader(c.stdin),
+		out:        c.stderr,
+		translator: c.translator(),
+	}
+}
+
+// usesPrompts reports whether an option or argument of n or below it
+// prompts or a command below it asks for a confirmation. Only then is
+// --no-input a global flag.
+func usesPrompts(n node) bool {
+	for _, opt := range n.options {
+		if opt.prompt {
+			return true
+		}
+	}
+	if n.argument != nil && (n.argument.prompt || usesPrompts(n.argument.node())) {
+		return true
+	}
+	for _, cmd := range n.command {
+		if cmd.hooks.confirm != nil || usesPrompts(cmd.node()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ask prompts with the description, or the name if there is none, until
+// the answer matches validate. An empty answer selects defaultValue if it
+// is set. It reports false if the input ended before a valid answer.
+// A secret is read without echo and redacted in the re-prompt.
+func (p *prompter) ask(name string, description, defaultValue *string, validate *regexp.Regexp, secret bool) (string, bool) {
+	label := name
+	if description != nil {
+		label = p.translator.text(*description)
+	}
+
+	for {
+		if defaultValue != nil && !secret {
+			io.WriteString(p.out, p.translator.message(MessagePromptDefault, label, *defaultValue))
+		} else {
+			io.WriteString(p.out, p.translator.message(MessagePrompt, label))
+		}
+
+		line, err := p.readLine(secret)
+		if err != nil && line == "" {
+			io.WriteString(p.out, "\n")
+			return "", false
+		}
+		answer := strings.TrimRight(line, "\r\n")
+
+		if answer == "" && defaultValue != nil {
+			return *defaultValue, true
+		}
+		if answer != "" && (validate == nil || validate.MatchString(answer)) {
+			return answer, true
+		}
+
+		if secret {
+			answer = redacted
+		}
+		io.WriteString(p.out, p.translator.message(MessageInvalidValue, name, answer)+"\n")
+		if err != nil {
+			return "", false
+		}
+	}
+}
+
+// readLine reads a line of input, without echo if secret is set.
+func (p *prompter) readLine(secret bool) (string, error) {
+	if !secret {
+		return p.in.ReadString('\n')
+	}
+
+	restore, err := term.DisableEcho(p.stdin)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	line, err := p.in.ReadString('\n')
+	if term.IsTerminal(p.stdin) {
+		io.WriteString(p.out, "\n")
+	}
+
+	return line, err
+}
+
+// promptOptions asks for the options of a leaf that have Prompt set but
+// were not given. index is the position of the end of the command line.
+func (ctx *Context) promptOptions(opts []*option, index int) error {
+	if ctx.prompter == nil {
+		return nil
+	}
+
+	for _, opt := range opts {
+		if !opt.prompt || ctx.UsedOption(opt.long) {
+			continue
+		}
+
+		value, ok := ctx.prompter.ask(opt.long, opt.description, opt.defaultValue, opt.validate, opt.secret)
+		if !ok {
+			return newParseError(ctx, "", index, "value for --"+opt.long, ErrUnexpectedEndCommand)
+		}
+		ctx.options[opt.long] = value
+		if opt.secret {
+			ctx.secrets[opt.long] = true
+		}
+	}
+
+	return nil
+}
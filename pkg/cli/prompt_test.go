@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromptMissing(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Argument", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := newTestCLI("Ada\nada\nus\n", true,
+			Stream(io.Discard, stderr),
+			Command("login", Argument("user",
+				Description("User name"),
+				Validate(regexp.MustCompile(`^[a-z]+$`)),
+				Prompt(),
+				Option("region", Default("eu"), Required(), Prompt()),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+		)
+		ctx, err := cli.RunWith([]string{"cli", "login"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "ada", *ctx.GetArgument("user"))
+		assert.Equal(t, "us", *ctx.GetOption("region"))
+		assert.Equal(t, "User name: invalid value for user: Ada\nUser name: region [eu]: ", stderr.String())
+	})
+
+	t.Run("Default", func(t *testing.T) {
+		t.Parallel()
+
+		cli := newTestCLI("\n", true,
+			Stream(io.Discard, io.Discard),
+			Command("login", Argument("user",
+				Description("User name"),
+				Validate(regexp.MustCompile(`^[a-z]+$`)),
+				Prompt(),
+				Option("region", Default("eu"), Required(), Prompt()),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+		)
+		ctx, err := cli.RunWith([]string{"cli", "login", "ada"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "eu", *ctx.GetOption("region"))
+	})
+
+	t.Run("Given", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := newTestCLI("", true,
+			Stream(io.Discard, stderr),
+			Command("login", Argument("user",
+				Description("User name"),
+				Validate(regexp.MustCompile(`^[a-z]+$`)),
+				Prompt(),
+				Option("region", Default("eu"), Required(), Prompt()),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+		)
+		ctx, err := cli.RunWith([]string{"cli", "login", "ada", "--region", "us"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "us", *ctx.GetOption("region"))
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("EndOfInput", func(t *testing.T) {
+		t.Parallel()
+
+		cli := newTestCLI("", true,
+			Stream(io.Discard, io.Discard),
+			Command("login", Argument("user",
+				Description("User name"),
+				Validate(regexp.MustCompile(`^[a-z]+$`)),
+				Prompt(),
+				Option("region", Default("eu"), Required(), Prompt()),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+		)
+		_, err := cli.RunWith([]string{"cli", "login"})
+
+		assert.ErrorIs(t, err, ErrUnexpectedEndCommand)
+	})
+
+	t.Run("NoInput", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := newTestCLI("ada\n", true,
+			Stream(io.Discard, stderr),
+			Command("login", Argument("user",
+				Description("User name"),
+				Validate(regexp.MustCompile(`^[a-z]+$`)),
+				Prompt(),
+				Option("region", Default("eu"), Required(), Prompt()),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+		)
+		_, err := cli.RunWith([]string{"cli", "--no-input", "login"})
+
+		assert.ErrorIs(t, err, ErrUnexpectedEndCommand)
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("NoTerminal", func(t *testing.T) {
+		t.Parallel()
+
+		cli := newTestCLI("ada\n", false,
+			Stream(io.Discard, io.Discard),
+			Command("login", Argument("user",
+				Description("User name"),
+				Validate(regexp.MustCompile(`^[a-z]+$`)),
+				Prompt(),
+				Option("region", Default("eu"), Required(), Prompt()),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+		)
+		_, err := cli.RunWith([]string{"cli", "login"})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, "argument <user>", parseErr.Expected)
+	})
+}
+
+func TestNoInputFlag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WithoutPrompts", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Command("sync", Option("no-input"), Handler(func(ctx *Context) error { return nil })))
+		ctx, err := cli.RunWith([]string{"cli", "sync", "--no-input"})
+
+		require.NoError(t, err)
+		assert.True(t, ctx.UsedOption("no-input"))
+	})
+
+	t.Run("ShadowedByCommandOption", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(
+			Command("login", Option("user", Prompt()), Handler(func(ctx *Context) error { return nil })),
+			Command("sync", Option("no-input"), Handler(func(ctx *Context) error { return nil })),
+		)
+		ctx, err := cli.RunWith([]string{"cli", "sync", "--no-input"})
+
+		require.NoError(t, err)
+		assert.True(t, ctx.UsedOption("no-input"))
+	})
+
+	t.Run("UsesPrompts", func(t *testing.T) {
+		t.Parallel()
+
+		assert.False(t, usesPrompts(New(Command("sync")).node()))
+		assert.True(t, usesPrompts(New(Command("a", Argument("b", Prompt()))).node()))
+		assert.True(t, usesPrompts(New(Command("a", Command("b", Confirm("Sure?")))).node()))
+	})
+}
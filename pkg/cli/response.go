@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strings"
+)
+
+// maxResponseFileDepth limits how deep response files may include others.
+const maxResponseFileDepth = 16
+
+// ErrResponseFileDepth is returned if response files include each other
+// deeper than the limit, e.g. because one includes itself.
+var ErrResponseFileDepth = errors.New("response files nested too deeply")
+
+// expandResponseFiles replaces every "@path" in args with the arguments
+// read from the file at path, split with SplitArgs. Files may include
+// other files. "@@x" stands for the literal argument "@x". Arguments after
+// a "--" terminator are left as they are. origin holds, for each expanded
+// argument, the index of the argument of args it stems from.
+func expandResponseFiles(args []string) (expanded []string, origin []int, err error) {
+	for i, arg := range args {
+		if arg == "--" {
+			expanded = append(expanded, args[i:]...)
+			for j := i; j < len(args); j++ {
+				origin = append(origin, j)
+			}
+			break
+		}
+
+		tokens, err := expandResponseFile(arg, 0)
+		if err != nil {
+			return nil, nil, &ParseError{Token: arg, Index: i, Expected: "readable response file", Err: err}
+		}
+		expanded = append(expanded, tokens...)
+		for range tokens {
+			origin = append(origin, i)
+		}
+	}
+
+	return expanded, origin, nil
+}
+
+func expandResponseFile(arg string, depth int) ([]string, error) {
+	if strings.HasPrefix(arg, "@@") {
+		return []string{arg[1:]}, nil
+	}
+	path, ok := strings.CutPrefix(arg, "@")
+	if !ok || path == "" {
+		return []string{arg}, nil
+	}
+	if depth >= maxResponseFileDepth {
+		return nil, ErrResponseFileDepth
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := SplitArgs(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	expanded := []string{}
+	for _, token := range tokens {
+		nested, err := expandResponseFile(token, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, nested...)
+	}
+
+	return expanded, nil
+}
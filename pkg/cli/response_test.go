@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	nested := write("nested.rsp", "--level 'very high'\n")
+	args := write("args.rsp", "# build flags\n--name \"my app\" @"+nested+"\n")
+	write("self.rsp", "@"+filepath.Join(dir, "self.rsp"))
+	invalid := write("invalid.rsp", "--level bad")
+
+	newCLI := func() *CLI {
+		return New(
+			Stream(&bytes.Buffer{}, &bytes.Buffer{}),
+			ResponseFiles(),
+			Command("build", Argument("target",
+				Option("name"),
+				Option("level", Validate(regexp.MustCompile(`^very`))),
+				Handler(func(ctx *Context) error { return nil }),
+			)),
+		)
+	}
+
+	t.Run("Expand", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, err := newCLI().RunWith([]string{"cli", "build", "@@target", "@" + args})
+
+		require.NoError(t, err)
+		assert.Equal(t, "@target", *ctx.GetArgument("target"))
+		assert.Equal(t, "my app", *ctx.GetOption("name"))
+		assert.Equal(t, "very high", *ctx.GetOption("level"))
+	})
+
+	t.Run("ErrorIndex", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newCLI().RunWith([]string{"cli", "build", "x", "@" + invalid})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 3, parseErr.Index)
+		assert.Equal(t, "bad", parseErr.Token)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newCLI().RunWith([]string{"cli", "build", "@" + filepath.Join(dir, "missing.rsp")})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 2, parseErr.Index)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("Recursion", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newCLI().RunWith([]string{"cli", "@" + filepath.Join(dir, "self.rsp")})
+
+		assert.ErrorIs(t, err, ErrResponseFileDepth)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, err := New(Command("build", Argument("target"))).RunWith([]string{"cli", "build", "@" + args})
+
+		require.NoError(t, err)
+		assert.Equal(t, "@"+args, *ctx.GetArgument("target"))
+	})
+
+	t.Run("Terminator", func(t *testing.T) {
+		t.Parallel()
+
+		expanded, origin, err := expandResponseFiles([]string{"@@a", "--", "@" + args})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"@a", "--", "@" + args}, expanded)
+		assert.Equal(t, []int{0, 1, 2}, origin)
+	})
+}
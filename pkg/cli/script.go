@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ScriptError is the error of a line of a script run with RunScript.
+type ScriptError struct {
+	// Line is the number of the line the command starts at, from 1.
+	Line    int
+	Command string
+	Err     error
+}
+
+func (e *ScriptError) Error() string {
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+func (e *ScriptError) Unwrap() error {
+	return e.Err
+}
+
+// ScriptErrors is returned by RunScript with ContinueOnError if any of the
+// commands failed.
+type ScriptErrors struct {
+	Errors []*ScriptError
+	// Commands is the number of commands that ran.
+	Commands int
+}
+
+func (e *ScriptErrors) Error() string {
+	return fmt.Sprintf("%d of %d commands failed", len(e.Errors), e.Commands)
+}
+
+func (e *ScriptErrors) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// RunScript reads one command line per line from r and runs each like
+// RunLine. Empty lines and comments are skipped and a line ending with a
+// backslash continues on the next one. Failed lines are reported with
+// their line number. RunScript stops at the first failure and returns its
+// *ScriptError, or with ContinueOnError runs all lines, prints a summary
+// and returns *ScriptErrors.
+func (c *CLI) RunScript(r io.Reader) error {
+	goCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	defer c.handleSignals(cancel)()
+
+	_, base, err := c.parseGlobalFlags([]string{c.Name()}, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.runScript(goCtx, base, r)
+}
+
+// runScript runs the lines of r with the global flags of base, which a
+// line may override.
+func (c *CLI) runScript(goCtx context.Context, base *runFlags, r io.Reader) error {
+	t := c.translator()
+	summary := &ScriptErrors{}
+	scanner := bufio.NewScanner(r)
+	number := 0
+
+	for scanner.Scan() {
+		number++
+		start := number
+		line := scanner.Text()
+		for strings.HasSuffix(line, "\\") && scanner.Scan() {
+			number++
+			line += "\n" + scanner.Text()
+		}
+
+		args, err := SplitArgs(line)
+		if err == nil && len(args) == 0 {
+			continue
+		}
+		summary.Commands++
+
+		argv := append([]string{c.Name()}, args...)
+		if err == nil {
+			_, err = c.runArgv(goCtx, base, argv)
+		}
+		if err == nil || errors.Is(err, ErrHelp) || errors.Is(err, ErrVersion) {
+			continue
+		}
+
+		io.WriteString(c.stderr, t.message(MessageScriptLine, start)+" ")
+		c.reportError(argv, err, c.colorMode(argv, base.color))
+
+		scriptErr := &ScriptError{Line: start, Command: line, Err: err}
+		if !c.continueOnError {
+			return scriptErr
+		}
+		summary.Errors = append(summary.Errors, scriptErr)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(summary.Errors) > 0 {
+		c.printError(errors.New(t.message(MessageScriptSummary, len(summary.Errors), summary.Commands)), base.color)
+		return summary
+	}
+
+	return nil
+}
+
+// runBatch runs the script at path, or stdin for "-", for --batch. No
+// other arguments may be given.
+func (c *CLI) runBatch(goCtx context.Context, flags *runFlags, rest *flagArgs, path string) error {
+	if len(rest.args) > 0 {
+		return &ParseError{
+			Token:    rest.args[0],
+			Index:    rest.argvIndex(0),
+			Expected: "no arguments besides --batch",
+			Err:      UnknownArgumentError(rest.args[0]),
+		}
+	}
+
+	if path == "-" {
+		return c.runScript(goCtx, flags, c.stdin)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return c.runScript(goCtx, flags, file)
+}
+
+// isReported reports whether err was already reported to the user, by
+// runScript or by a plugin, so MustRun only has to exit.
+func isReported(err error) bool {
+	var scriptErr *ScriptError
+	var scriptErrs *ScriptErrors
+	var pluginErr *PluginError
+
+	return errors.As(err, &scriptErr) || errors.As(err, &scriptErrs) || errors.As(err, &pluginErr)
+}
@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testScript = `# migrate the users
+create alice
+create bob \
+  --admin
+fetch carol
+create 'dave
+create eve
+`
+
+func TestRunScript(t *testing.T) {
+	t.Parallel()
+
+	t.Run("StopOnError", func(t *testing.T) {
+		t.Parallel()
+
+		created := []string{}
+		stderr := &bytes.Buffer{}
+		cli := New(
+			Name("users"),
+			Stream(io.Discard, stderr),
+			Command("create", Argument("name",
+				Option("admin"),
+				Handler(func(ctx *Context) error {
+					name := *ctx.GetArgument("name")
+					if ctx.UsedOption("admin") {
+						name += " (admin)"
+					}
+					created = append(created, name)
+					return nil
+				}),
+			)),
+		)
+		err := cli.RunScript(strings.NewReader(testScript))
+
+		var scriptErr *ScriptError
+		require.ErrorAs(t, err, &scriptErr)
+		assert.Equal(t, 5, scriptErr.Line)
+		assert.Equal(t, "fetch carol", scriptErr.Command)
+		assert.ErrorIs(t, err, UnknownCommandError("fetch"))
+		assert.EqualError(t, err, "line 5: unknown command: fetch")
+		assert.Equal(t, []string{"alice", "bob (admin)"}, created)
+		assert.Equal(t, "line 5: unknown command: fetch\n  users fetch carol\n        ^^^^^ expected command\n", stderr.String())
+	})
+
+	t.Run("ContinueOnError", func(t *testing.T) {
+		t.Parallel()
+
+		created := []string{}
+		stderr := &bytes.Buffer{}
+		cli := New(
+			Name("users"),
+			Stream(io.Discard, stderr),
+			ContinueOnError(),
+			Command("create", Argument("name",
+				Option("admin"),
+				Handler(func(ctx *Context) error {
+					name := *ctx.GetArgument("name")
+					if ctx.UsedOption("admin") {
+						name += " (admin)"
+					}
+					created = append(created, name)
+					return nil
+				}),
+			)),
+		)
+		err := cli.RunScript(strings.NewReader(testScript))
+
+		var scriptErrs *ScriptErrors
+		require.ErrorAs(t, err, &scriptErrs)
+		assert.Equal(t, 5, scriptErrs.Commands)
+		require.Len(t, scriptErrs.Errors, 2)
+		assert.Equal(t, 5, scriptErrs.Errors[0].Line)
+		assert.Equal(t, 6, scriptErrs.Errors[1].Line)
+		assert.ErrorIs(t, err, ErrUnterminatedQuote)
+		assert.Equal(t, []string{"alice", "bob (admin)", "eve"}, created)
+		assert.Contains(t, stderr.String(), "line 6: unterminated quote\n")
+		assert.True(t, strings.HasSuffix(stderr.String(), "2 of 5 commands failed\n"))
+		assert.Equal(t, ExitUsage, cli.exitCode(err))
+	})
+
+	t.Run("Batch", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "script")
+		require.NoError(t, os.WriteFile(path, []byte("create alice\ncreate bob\n"), 0o600))
+
+		created := []string{}
+		cli := New(
+			Name("users"),
+			Batch(),
+			Command("create", Argument("name", Handler(func(ctx *Context) error {
+				created = append(created, *ctx.GetArgument("name"))
+				return nil
+			}))),
+		)
+		_, err := cli.RunWith([]string{"users", "--batch", path})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"alice", "bob"}, created)
+	})
+
+	t.Run("BatchStdin", func(t *testing.T) {
+		t.Parallel()
+
+		created := []string{}
+		cli := newTestCLI("create carol\n", false,
+			Name("users"),
+			Batch(),
+			Command("create", Argument("name", Handler(func(ctx *Context) error {
+				created = append(created, *ctx.GetArgument("name"))
+				return nil
+			}))),
+		)
+		_, err := cli.RunWith([]string{"users", "--batch=-"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"carol"}, created)
+	})
+
+	t.Run("BatchGlobalFlags", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "script")
+		require.NoError(t, os.WriteFile(path, []byte("show\nshow --output json\nfail\n"), 0o600))
+
+		prompting := []bool{}
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cli := newTestCLI("", true,
+			Stream(stdout, stderr),
+			Colors(DefaultTheme),
+			Output(OutputJSON),
+			Batch(),
+			ContinueOnError(),
+			Command("show", Handler(func(ctx *Context) error {
+				prompting = append(prompting, ctx.prompter != nil)
+				return ctx.Output(map[string]int{"a": 1})
+			})),
+			Command("drop", Confirm("Drop?"), Handler(func(ctx *Context) error { return nil })),
+		)
+		_, err := cli.RunWith([]string{"users", "--output", "yaml", "--no-input", "--color=always", "--batch", path})
+
+		assert.ErrorIs(t, err, UnknownCommandError("fail"))
+		assert.Equal(t, "a: 1\n{\n  \"a\": 1\n}\n", stdout.String())
+		assert.Equal(t, []bool{false, false}, prompting)
+		assert.Contains(t, stderr.String(), DefaultTheme.Error.Apply("unknown command: fail"))
+	})
+
+	t.Run("NestedBatch", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "script")
+		require.NoError(t, os.WriteFile(path, []byte("create a\n--batch "+path+"\n"), 0o600))
+
+		created := []string{}
+		stderr := &bytes.Buffer{}
+		cli := New(
+			Name("users"),
+			Stream(io.Discard, stderr),
+			Batch(),
+			Command("create", Argument("name", Handler(func(ctx *Context) error {
+				created = append(created, *ctx.GetArgument("name"))
+				return nil
+			}))),
+		)
+		_, err := cli.RunWith([]string{"users", "--batch", path})
+
+		var scriptErr *ScriptError
+		require.ErrorAs(t, err, &scriptErr)
+		assert.Equal(t, 2, scriptErr.Line)
+		assert.ErrorIs(t, err, UnknownArgumentError("--batch"))
+		assert.Equal(t, []string{"a"}, created)
+		assert.Contains(t, stderr.String(), "expected no --batch in a script")
+	})
+
+	t.Run("BatchWithArguments", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Name("users"), Batch(), Command("create", Argument("name")))
+		_, err := cli.RunWith([]string{"users", "--batch", "-", "create", "x"})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, 3, parseErr.Index)
+		assert.Equal(t, "create", parseErr.Token)
+	})
+
+	t.Run("MustRun", func(t *testing.T) {
+		t.Parallel()
+
+		code := -1
+		created := []string{}
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cli := newTestCLI("create alice\nfetch\n", false,
+			Name("users"),
+			Stream(stdout, stderr),
+			Batch(),
+			ExitFunc(func(c int) { code = c }),
+			Command("create", Argument("name", Handler(func(ctx *Context) error {
+				created = append(created, *ctx.GetArgument("name"))
+				return nil
+			}))),
+		)
+		cli.MustRunWith([]string{"users", "--batch", "-"})
+
+		assert.Equal(t, ExitUsage, code)
+		assert.Equal(t, 1, strings.Count(stderr.String(), "unknown command: fetch"))
+		assert.Empty(t, stdout.String())
+	})
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// redacted replaces the values of secret options in errors, diagnostics
+// and Context.String.
+const redacted = "***"
+
+// readSecretFile reads the value of a secret option from path, or from
+// stdin if path is "-". A single trailing line break is removed.
+func (ctx *Context) readSecretFile(path string) (string, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		stdin := ctx.stdin
+		if stdin == nil {
+			stdin = os.Stdin
+		}
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	value := strings.TrimSuffix(string(content), "\n")
+
+	return strings.TrimSuffix(value, "\r"), nil
+}
+
+// secretValues returns the values of the secret options given so far.
+func (ctx *Context) secretValues() []string {
+	var values []string
+	for name := range ctx.secrets {
+		if value, ok := ctx.options[name]; ok && value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// secretFlags returns the long and short flags of the secret options of n
+// and below it, so their values can be redacted in a command line that
+// failed to parse before reaching them.
+func secretFlags(n node) map[string]bool {
+	flags := map[string]bool{}
+	for _, opt := range n.options {
+		if !opt.secret {
+			continue
+		}
+		flags["--"+opt.long] = true
+		if opt.short != nil {
+			flags["-"+string(*opt.short)] = true
+		}
+	}
+
+	children := []node{}
+	if n.argument != nil {
+		children = append(children, n.argument.node())
+	}
+	for _, cmd := range n.command {
+		children = append(children, cmd.node())
+	}
+	for _, child := range children {
+		for flag := range secretFlags(child) {
+			flags[flag] = true
+		}
+	}
+
+	return flags
+}
+
+// String describes the matched commands, arguments and options for debug
+// output and audit logs, with the values of secret options redacted.
+func (ctx *Context) String() string {
+	sb := strings.Builder{}
+	sb.WriteString("commands=[" + strings.Join(ctx.commands, " ") + "]")
+
+	sb.WriteString(" arguments={")
+	writeSorted(&sb, ctx.arguments, nil)
+	sb.WriteString("} options={")
+	writeSorted(&sb, ctx.options, ctx.secrets)
+	sb.WriteString("}")
+
+	return sb.String()
+}
+
+func writeSorted(sb *strings.Builder, values map[string]string, secrets map[string]bool) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		if i > 0 {
+			sb.WriteString(" ")
+		}
+		value := values[key]
+		if secrets[key] {
+			value = redacted
+		}
+		sb.WriteString(key + "=" + displayArg(value))
+	}
+}
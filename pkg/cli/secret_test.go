@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretOption(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RedactedError", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := New(
+			Name("deploy"),
+			Stream(io.Discard, stderr),
+			ExitFunc(func(code int) {}),
+			Option("token", Secret(), Validate(regexp.MustCompile(`^tok_`))),
+			Handler(func(ctx *Context) error { return nil }),
+		)
+		_, err := cli.RunWith([]string{"deploy", "--token", "hunter2"})
+
+		var parseErr *ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, redacted, parseErr.Token)
+		assert.EqualError(t, err, "invalid value for token: ***")
+
+		cli.MustRunWith([]string{"deploy", "--token", "hunter2"})
+		assert.NotContains(t, stderr.String(), "hunter2")
+		assert.Contains(t, stderr.String(), "deploy --token ***\n")
+	})
+
+	t.Run("RedactedDiagnostic", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := New(
+			Stream(&bytes.Buffer{}, stderr),
+			ExitFunc(func(code int) {}),
+			Command("login", Argument("user",
+				Option("token", Secret()),
+				Option("env", Validate(regexp.MustCompile(`^(dev|prod)$`))),
+			)),
+		)
+		cli.MustRunWith([]string{"cli", "login", "ada", "--token", "hunter2", "--env", "qa"})
+
+		assert.NotContains(t, stderr.String(), "hunter2")
+		assert.Contains(t, stderr.String(), "cli login ada --token *** --env qa\n")
+	})
+
+	t.Run("RedactedDiagnosticAfterError", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := New(
+			Name("t"),
+			Stream(&bytes.Buffer{}, stderr),
+			ExitFunc(func(code int) {}),
+			Command("deploy",
+				Option("region", Validate(regexp.MustCompile(`^[a-z]+$`))),
+				Option("token", Short('t'), Secret()),
+				Handler(func(ctx *Context) error { return nil }),
+			),
+		)
+		cli.MustRunWith([]string{"t", "deploy", "--region", "BAD!", "--token", "s3cr3t", "-t", "s3cr3t2", "--token=s3cr3t3"})
+
+		assert.NotContains(t, stderr.String(), "s3cr3t")
+		assert.Contains(t, stderr.String(), "t deploy --region BAD! --token *** -t *** --token=***\n")
+	})
+
+	t.Run("File", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(path, []byte("tok_file\n"), 0o600))
+
+		cli := New(
+			Option("token", Secret(), Validate(regexp.MustCompile(`^tok_`))),
+			Handler(func(ctx *Context) error { return nil }),
+		)
+		ctx, err := cli.RunWith([]string{"deploy", "--token-file", path})
+
+		require.NoError(t, err)
+		assert.Equal(t, "tok_file", *ctx.GetOption("token"))
+	})
+
+	t.Run("Stdin", func(t *testing.T) {
+		t.Parallel()
+
+		cli := newTestCLI("tok_stdin\r\n", false,
+			Option("token", Secret()),
+			Handler(func(ctx *Context) error { return nil }),
+		)
+		ctx, err := cli.RunWith([]string{"deploy", "--token-file", "-"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "tok_stdin", *ctx.GetOption("token"))
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(
+			Option("token", Secret()),
+			Handler(func(ctx *Context) error { return nil }),
+		)
+		_, err := cli.RunWith([]string{"deploy", "--token-file", filepath.Join(t.TempDir(), "missing")})
+
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(
+			Option("token", Secret()),
+			Option("env"),
+			Handler(func(ctx *Context) error { return nil }),
+		)
+		ctx, err := cli.RunWith([]string{"deploy", "--token", "tok_1", "--env", "prod env"})
+
+		require.NoError(t, err)
+		assert.Equal(t, `commands=[] arguments={} options={env="prod env" token=***}`, ctx.String())
+	})
+
+	t.Run("Help", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(
+			Stream(stdout, io.Discard),
+			Option("token", Secret(), Default("s3cr3t-default"), Description("API token")),
+		)
+		cli.PrintHelp()
+
+		assert.Contains(t, stdout.String(), "API token")
+		assert.NotContains(t, stdout.String(), "s3cr3t-default")
+	})
+
+	t.Run("Prompt", func(t *testing.T) {
+		t.Parallel()
+
+		stderr := &bytes.Buffer{}
+		cli := newTestCLI("hunter2\ntok_1\n", true,
+			Stream(io.Discard, stderr),
+			Option("token", Secret(), Prompt(), Default("tok_default"), Validate(regexp.MustCompile(`^tok_`))),
+			Handler(func(ctx *Context) error { return nil }),
+		)
+		ctx, err := cli.RunWith([]string{"cli"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "tok_1", *ctx.GetOption("token"))
+		assert.Equal(t, "token: invalid value for token: ***\ntoken: ", stderr.String())
+		assert.Equal(t, "options={token=***}", ctx.String()[strings.Index(ctx.String(), "options"):])
+	})
+}
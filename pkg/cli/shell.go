@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/term"
+)
+
+// shellHistoryLimit is the number of lines loaded from the history file.
+const shellHistoryLimit = 1000
+
+// RunShell starts an interactive shell reading command lines from stdin.
+// Each line is split like a POSIX shell would and run like RunWith with
+// the line as arguments. Errors are printed and help does not end the
+// shell, "exit" and "quit" or the end of input do, unless they are
+// commands of the CLI. On a terminal, lines can be edited, the history
+// browsed with the arrow keys and commands and options completed with tab.
+func (c *CLI) RunShell() error {
+	history := c.loadHistory()
+	readLine := c.shellReader(&history)
+
+	for {
+		line, err := readLine()
+		if errors.Is(err, term.ErrInterrupted) {
+			continue
+		} else if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		args, err := SplitArgs(line)
+		if err != nil {
+			c.printError(err, ColorAuto)
+			continue
+		}
+		if len(args) == 0 {
+			continue
+		}
+		history = append(history, line)
+		c.appendHistory(line)
+
+		if !c.hasCommand(args[0]) {
+			switch args[0] {
+			case "exit", "quit":
+				return nil
+			case "help":
+				c.PrintHelp()
+				continue
+			}
+		}
+
+		argv := append([]string{c.Name()}, args...)
+		if _, err := c.RunWith(argv); err != nil && !errors.Is(err, ErrHelp) && !errors.Is(err, ErrVersion) {
+			c.reportError(argv, err, c.colorMode(argv, ColorAuto))
+		}
+	}
+}
+
+// shellReader returns a function reading the next line. On a terminal it
+// uses a term.LineEditor in raw mode, otherwise lines are read as they are
+// without showing the prompt.
+func (c *CLI) shellReader(history *[]string) func() (string, error) {
+	if !c.isTerminal(c.stdin) {
+		in := bufio.NewReader(c.stdin)
+		return func() (string, error) {
+			line, err := in.ReadString('\n')
+			if err != nil && line == "" {
+				return "", err
+			}
+
+			return strings.TrimRight(line, "\r\n"), nil
+		}
+	}
+
+	editor := term.NewLineEditor(c.stdin, c.stdout)
+	editor.Complete = c.complete
+	prompt := c.Name() + "> "
+	if c.shellPrompt != nil {
+		prompt = *c.shellPrompt
+	}
+
+	return func() (string, error) {
+		restore, err := term.MakeRaw(c.stdin)
+		if err != nil {
+			return "", err
+		}
+		defer restore()
+
+		editor.History = *history
+
+		return editor.ReadLine(prompt)
+	}
+}
+
+// complete returns the commands, plugins and aliases or, for a word starting with
+// "-", the options that can follow line.
+func (c *CLI) complete(line string) []string {
+	words := strings.Fields(line)
+	word := ""
+	if len(words) > 0 && !strings.HasSuffix(line, " ") {
+		word = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	n := c.node()
+	for _, w := range words {
+		if strings.HasPrefix(w, "-") {
+			continue
+		}
+		if n.argument != nil {
+			n = n.argument.node()
+			continue
+		}
+
+		found := false
+		for _, cmd := range n.command {
+			if cmd.name == w {
+				n = cmd.node()
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+
+	candidates := []string{}
+	if strings.HasPrefix(word, "-") {
+		if n.argument == nil && len(n.command) == 0 {
+			for _, opt := range n.options {
+				if strings.HasPrefix("--"+opt.long, word) {
+					candidates = append(candidates, "--"+opt.long)
+				}
+			}
+		}
+	} else {
+		for _, cmd := range n.command {
+			if strings.HasPrefix(cmd.name, word) {
+				candidates = append(candidates, cmd.name)
+			}
+		}
+		if c.plugins && len(words) == 0 {
+			for _, name := range c.discoverPlugins() {
+				if strings.HasPrefix(name, word) {
+					candidates = append(candidates, name)
+				}
+			}
+		}
+		if c.aliasFile != nil && len(words) == 0 {
+			aliases, _ := c.readAliases()
+			for _, name := range c.aliasNames(aliases) {
+				if strings.HasPrefix(name, word) {
+					candidates = append(candidates, name)
+				}
+			}
+		}
+	}
+	sort.Strings(candidates)
+
+	return candidates
+}
+
+func (c *CLI) hasCommand(name string) bool {
+	for _, cmd := range c.command {
+		if cmd.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// loadHistory returns the last lines of the history file.
+func (c *CLI) loadHistory() []string {
+	if c.shellHistory == nil {
+		return nil
+	}
+
+	content, err := os.ReadFile(*c.shellHistory)
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) > shellHistoryLimit {
+		lines = lines[len(lines)-shellHistoryLimit:]
+	}
+
+	return lines
+}
+
+// appendHistory adds line to the history file. Failures are ignored, the
+// history is a convenience only.
+func (c *CLI) appendHistory(line string) {
+	if c.shellHistory == nil {
+		return
+	}
+
+	file, err := os.OpenFile(*c.shellHistory, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	io.WriteString(file, line+"\n")
+}
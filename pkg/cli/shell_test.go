@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunShell(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Script", func(t *testing.T) {
+		t.Parallel()
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		cli := newTestCLI("get 'http://x y'\n\nget --help\nfetch\nget \"x\nhelp\nexit\nget never\n", false,
+			Name("gurl"),
+			Stream(stdout, stderr),
+			ExitFunc(func(code int) { panic("exited") }),
+			Command("get", Description("Get a resource"), Argument("url", Handler(func(ctx *Context) error {
+				stdout.WriteString("GET " + *ctx.GetArgument("url") + "\n")
+				return nil
+			}))),
+		)
+
+		require.NoError(t, cli.RunShell())
+		assert.Contains(t, stdout.String(), "GET http://x y\n")
+		assert.NotContains(t, stdout.String(), "GET never")
+		assert.Contains(t, stdout.String(), "Usage:\n  gurl get <argument>")
+		assert.Contains(t, stdout.String(), "Get a resource")
+		assert.Equal(t, "unknown command: fetch\n  gurl fetch\n       ^^^^^ expected command\nunterminated quote\n", stderr.String())
+	})
+
+	t.Run("EndOfInput", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := newTestCLI("get x", false,
+			Stream(stdout, io.Discard),
+			Command("get", Argument("url", Handler(func(ctx *Context) error {
+				stdout.WriteString("GET " + *ctx.GetArgument("url") + "\n")
+				return nil
+			}))),
+		)
+
+		require.NoError(t, cli.RunShell())
+		assert.Equal(t, "GET x\n", stdout.String())
+	})
+
+	t.Run("Terminal", func(t *testing.T) {
+		t.Parallel()
+
+		history := filepath.Join(t.TempDir(), "history")
+		require.NoError(t, os.WriteFile(history, []byte("get old\n"), 0o600))
+
+		stdout := &bytes.Buffer{}
+		cli := newTestCLI("\x1b[A\rg\tx --verb\t\r\x04", true,
+			Stream(stdout, io.Discard),
+			ShellPrompt("$ "),
+			ShellHistory(history),
+			Command("get", Argument("url", Option("verbose"), Handler(func(ctx *Context) error {
+				stdout.WriteString("GET " + *ctx.GetArgument("url") + "\n")
+				return nil
+			}))),
+		)
+
+		require.NoError(t, cli.RunShell())
+		assert.Contains(t, stdout.String(), "GET old\n")
+		assert.Contains(t, stdout.String(), "\r$ get x --verbose \x1b[K")
+		assert.Contains(t, stdout.String(), "GET x\n")
+
+		content, err := os.ReadFile(history)
+		require.NoError(t, err)
+		assert.Equal(t, "get old\nget old\nget x --verbose \n", string(content))
+	})
+}
+
+func TestComplete(t *testing.T) {
+	t.Parallel()
+
+	cli := New(
+		Command("get", Argument("url", Option("verbose"), Option("version"))),
+		Command("config", Command("set"), Command("show")),
+	)
+
+	for line, expected := range map[string][]string{
+		"":                 {"config", "get"},
+		"co":               {"config"},
+		"config ":          {"set", "show"},
+		"config s":         {"set", "show"},
+		"get x --ver":      {"--verbose", "--version"},
+		"get x --verbose ": {},
+		"unknown ":         nil,
+	} {
+		assert.Equal(t, expected, cli.complete(line), line)
+	}
+}
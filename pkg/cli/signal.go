@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+// handleSignals cancels the run on the first SIGINT or SIGTERM. A second
+// signal, or the end of the grace period set with GracePeriod, exits through
+// the ExitFunc with ExitInterrupted. The returned function stops listening.
+func (c *CLI) handleSignals(cancel context.CancelFunc) (stop func()) {
+	signals := make(chan os.Signal, 2)
+	c.notify(signals, os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-done:
+			return
+		}
+
+		var grace <-chan time.Time
+		if c.gracePeriod > 0 {
+			timer := time.NewTimer(c.gracePeriod)
+			defer timer.Stop()
+			grace = timer.C
+		}
+
+		select {
+		case <-signals:
+			c.exit(ExitInterrupted)
+		case <-grace:
+			c.exit(ExitInterrupted)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		signal.Stop(signals)
+		close(done)
+	}
+}
@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sendSignals returns a notify function that delivers signals to the CLI
+// as soon as it starts listening.
+func sendSignals(signals ...os.Signal) func(c chan<- os.Signal, sig ...os.Signal) {
+	return func(c chan<- os.Signal, sig ...os.Signal) {
+		go func() {
+			for _, s := range signals {
+				c <- s
+			}
+		}()
+	}
+}
+
+func TestRunContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Parent", func(t *testing.T) {
+		t.Parallel()
+
+		type key struct{}
+		var value interface{}
+		cli := New(Handler(func(ctx *Context) error {
+			value = ctx.Context().Value(key{})
+			return nil
+		}))
+		_, err := cli.RunContext(context.WithValue(context.Background(), key{}, "value"), []string{"cli"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "value", value)
+	})
+
+	t.Run("Interrupt", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Handler(func(ctx *Context) error {
+			<-ctx.Context().Done()
+			return ctx.Context().Err()
+		}))
+		cli.notify = sendSignals(os.Interrupt)
+		_, err := cli.RunWith([]string{"cli"})
+
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("SecondSignal", func(t *testing.T) {
+		t.Parallel()
+
+		exited := make(chan int, 1)
+		cli := New(
+			ExitFunc(func(code int) { exited <- code }),
+			Handler(func(ctx *Context) error {
+				<-ctx.Context().Done()
+				assert.Equal(t, ExitInterrupted, <-exited)
+				return nil
+			}),
+		)
+		cli.notify = sendSignals(os.Interrupt, os.Interrupt)
+		_, err := cli.RunWith([]string{"cli"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("GracePeriod", func(t *testing.T) {
+		t.Parallel()
+
+		exited := make(chan int, 1)
+		cli := New(
+			GracePeriod(time.Millisecond),
+			ExitFunc(func(code int) { exited <- code }),
+			Handler(func(ctx *Context) error {
+				<-ctx.Context().Done()
+				assert.Equal(t, ExitInterrupted, <-exited)
+				return nil
+			}),
+		)
+		cli.notify = sendSignals(os.Interrupt)
+		_, err := cli.RunWith([]string{"cli"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("NoSignal", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, err := New(Handler(func(ctx *Context) error {
+			return ctx.Context().Err()
+		})).RunWith([]string{"cli"})
+
+		assert.NoError(t, err)
+		assert.ErrorIs(t, ctx.Context().Err(), context.Canceled)
+	})
+}
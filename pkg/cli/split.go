@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SplitArgs splits line into arguments like a POSIX shell, without
+// expansions. Whitespace separates arguments, single quotes preserve their
+// content literally and in double quotes a backslash only escapes ", \, $
+// and `. Outside of quotes, a backslash escapes any character and a word
+// starting with # comments out the rest of the line. It returns
+// ErrUnterminatedQuote if a quote is not closed.
+func SplitArgs(line string) ([]string, error) {
+	args := []string{}
+	current := strings.Builder{}
+	inArg := false
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		case r == '#' && !inArg:
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '\\' && i+1 < len(runes) && runes[i+1] == '\n':
+			// A line continuation.
+			i++
+		case r == '\\':
+			inArg = true
+			if i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+			}
+		case r == '\'':
+			inArg = true
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, ErrUnterminatedQuote
+			}
+			current.WriteString(string(runes[i+1 : end]))
+			i = end
+		case r == '"':
+			inArg = true
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune("\"\\$`", runes[i+1]) {
+					i++
+				}
+				current.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, ErrUnterminatedQuote
+			}
+		default:
+			inArg = true
+			current.WriteRune(r)
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitArgs(t *testing.T) {
+	t.Parallel()
+
+	for name, tc := range map[string]struct {
+		line     string
+		expected []string
+		err      error
+	}{
+		"Empty":        {"  ", []string{}, nil},
+		"Words":        {" get  http://x -v ", []string{"get", "http://x", "-v"}, nil},
+		"SingleQuotes": {`echo 'a "b" \c'`, []string{"echo", `a "b" \c`}, nil},
+		"DoubleQuotes": {`echo "a 'b' \"c\" \d"`, []string{"echo", `a 'b' "c" \d`}, nil},
+		"Escaped":      {`echo a\ b \'c`, []string{"echo", "a b", "'c"}, nil},
+		"Adjacent":     {`--name="a b"'c'`, []string{"--name=a bc"}, nil},
+		"EmptyQuotes":  {`set "" ''`, []string{"set", "", ""}, nil},
+		"Comment":      {"get x # the rest\nget 'y#z' a#b", []string{"get", "x", "get", "y#z", "a#b"}, nil},
+		"Continuation": {"get \\\n  x", []string{"get", "x"}, nil},
+		"Unterminated": {`echo "a`, nil, ErrUnterminatedQuote},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			args, err := SplitArgs(tc.line)
+			assert.Equal(t, tc.err, err)
+			assert.Equal(t, tc.expected, args)
+		})
+	}
+}
+
+func TestRunLine(t *testing.T) {
+	t.Parallel()
+
+	var url string
+	cli := New(Command("get", Argument("url",
+		Option("verbose", Short('v')),
+		Handler(func(ctx *Context) error {
+			url = *ctx.GetArgument("url")
+			return nil
+		}),
+	)))
+
+	ctx, err := cli.RunLine(`get 'http://x y' -v # verbose`)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://x y", url)
+	assert.True(t, ctx.UsedOption("verbose"))
+
+	_, err = cli.RunLine(`get "http://x`)
+	assert.Equal(t, ErrUnterminatedQuote, err)
+	assert.Equal(t, ExitUsage, cli.exitCode(err))
+}
@@ -0,0 +1,26 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/StevenCyb/GoCLI/pkg/cli/internal/term"
+)
+
+// defaultWidth is used if the width of the terminal cannot be determined,
+// e.g. because the output is redirected into a file.
+const defaultWidth = 80
+
+// terminalWidth returns the column count help output is wrapped to.
+// The COLUMNS environment variable takes precedence over the size of the
+// terminal stdout is connected to.
+func (c *CLI) terminalWidth() int {
+	if columns, err := strconv.Atoi(c.getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	if width, ok := term.Width(c.stdout); ok {
+		return width
+	}
+
+	return defaultWidth
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTerminalWidth(t *testing.T) {
+	t.Run("Fallback", func(t *testing.T) {
+		t.Setenv("COLUMNS", "")
+
+		assert.Equal(t, defaultWidth, New(Stream(&bytes.Buffer{}, &bytes.Buffer{})).terminalWidth())
+	})
+
+	t.Run("ColumnsOverride", func(t *testing.T) {
+		t.Setenv("COLUMNS", "120")
+
+		assert.Equal(t, 120, New(Stream(&bytes.Buffer{}, &bytes.Buffer{})).terminalWidth())
+	})
+
+	t.Run("InvalidColumns", func(t *testing.T) {
+		t.Setenv("COLUMNS", "wide")
+
+		assert.Equal(t, defaultWidth, New(Stream(&bytes.Buffer{}, &bytes.Buffer{})).terminalWidth())
+	})
+}
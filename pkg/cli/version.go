@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"runtime/debug"
+	"slices"
+	"strings"
+)
+
+// VersionInfo is the information printed by the --version flag and the
+// version command. The build fields are only set if requested with --build.
+type VersionInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Revision, Time and Modified describe the VCS state the binary was
+	// built from, as recorded by the Go toolchain.
+	Revision     string              `json:"revision,omitempty"`
+	Time         string              `json:"time,omitempty"`
+	Modified     bool                `json:"modified,omitempty"`
+	GoVersion    string              `json:"goVersion,omitempty"`
+	Dependencies []VersionDependency `json:"dependencies,omitempty"`
+}
+
+// VersionDependency is a module the binary was built with.
+type VersionDependency struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+}
+
+// readBuildInfo is replaced in tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// versionInfo collects the version information. If no version was
+// configured, the version of the main module is used.
+func (c *CLI) versionInfo(build bool) VersionInfo {
+	info := VersionInfo{Name: c.Name()}
+	buildInfo, ok := readBuildInfo()
+
+	if c.version != nil {
+		info.Version = *c.version
+	} else if ok {
+		info.Version = buildInfo.Main.Version
+	}
+
+	if !build || !ok {
+		return info
+	}
+
+	info.GoVersion = buildInfo.GoVersion
+	for _, setting := range buildInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.Time = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		info.Dependencies = append(info.Dependencies, VersionDependency{Path: dep.Path, Version: dep.Version})
+	}
+
+	return info
+}
+
+func (c *CLI) printVersion(w io.Writer, build, asJSON bool) error {
+	info := c.versionInfo(build)
+
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(info.Name + " " + info.Version + "\n")
+	if info.Revision != "" {
+		sb.WriteString("revision:     " + info.Revision)
+		if info.Modified {
+			sb.WriteString(" (modified)")
+		}
+		sb.WriteString("\n")
+	}
+	if info.Time != "" {
+		sb.WriteString("time:         " + info.Time + "\n")
+	}
+	if info.GoVersion != "" {
+		sb.WriteString("go:           " + info.GoVersion + "\n")
+	}
+	if len(info.Dependencies) > 0 {
+		sb.WriteString("dependencies:\n")
+		for _, dep := range info.Dependencies {
+			sb.WriteString("  " + dep.Path + " " + dep.Version + "\n")
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+// isVersionFlag reports whether arg is the --version or -V flag. The flag
+// is available whether or not a version was configured, but an option of
+// the CLI with the same name takes precedence.
+func (c *CLI) isVersionFlag(arg string) bool {
+	for _, opt := range c.options {
+		if arg == "--"+opt.long || opt.short != nil && arg == "-"+string(*opt.short) {
+			return false
+		}
+	}
+
+	return arg == "--version" || arg == "-V"
+}
+
+// printVersionFlag handles the --version flag, args are the arguments
+// following it.
+func (c *CLI) printVersionFlag(args []string) error {
+	if err := c.printVersion(c.stdout, slices.Contains(args, "--build"), slices.Contains(args, "--json")); err != nil {
+		return err
+	}
+
+	return ErrVersion
+}
+
+func (c *CLI) versionCommand() *command {
+	return Command("version",
+		Description("Print the version"),
+		Option("build", Description("Include VCS revision, Go version and dependencies")),
+		Option("json", Description("Print the version information as JSON")),
+		Handler(func(ctx *Context) error {
+			return c.printVersion(c.stdout, ctx.UsedOption("build"), ctx.UsedOption("json"))
+		}),
+	)
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersion_Flag(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Long", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(Name("gurl"), Version("1.2.3"), Stream(stdout, stdout), Command("get"))
+		ctx, err := cli.RunWith([]string{"gurl", "--version"})
+
+		assert.Nil(t, ctx)
+		assert.ErrorIs(t, err, ErrVersion)
+		assert.Equal(t, "gurl 1.2.3\n", stdout.String())
+	})
+
+	t.Run("ShortJSON", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(Name("gurl"), Version("1.2.3"), Stream(stdout, stdout))
+		_, err := cli.RunWith([]string{"gurl", "-V", "--json"})
+		assert.ErrorIs(t, err, ErrVersion)
+
+		info := VersionInfo{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &info))
+		assert.Equal(t, VersionInfo{Name: "gurl", Version: "1.2.3"}, info)
+	})
+
+	t.Run("WithoutVersion", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(Name("gurl"), Stream(stdout, stdout), Command("get"))
+		_, err := cli.RunWith([]string{"gurl", "-V"})
+
+		assert.ErrorIs(t, err, ErrVersion)
+		assert.Equal(t, "gurl "+cli.versionInfo(false).Version+"\n", stdout.String())
+	})
+
+	t.Run("ShadowedByOption", func(t *testing.T) {
+		t.Parallel()
+
+		cli := New(Option("version"))
+		ctx, err := cli.RunWith([]string{"cli", "--version"})
+
+		require.NoError(t, err)
+		assert.True(t, ctx.UsedOption("version"))
+	})
+
+	t.Run("MustRunExitsWithZero", func(t *testing.T) {
+		t.Parallel()
+
+		code := -1
+		cli := New(Version("1.0.0"), Stream(&bytes.Buffer{}, &bytes.Buffer{}), ExitFunc(func(c int) { code = c }))
+		cli.MustRunWith([]string{"cli", "--version"})
+
+		assert.Equal(t, 0, code)
+	})
+}
+
+func TestVersion_Command(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Plain", func(t *testing.T) {
+		t.Parallel()
+
+		stdout := &bytes.Buffer{}
+		cli := New(Name("gurl"), Version("1.2.3"), Stream(stdout, stdout), VersionCommand(), Command("get"))
+		_, err := cli.RunWith([]string{"gurl", "version"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "gurl 1.2.3\n", stdout.String())
+		assert.Equal(t, "version", cli.command[1].name)
+	})
+
+	t.Run("FlagOrder", func(t *testing.T) {
+		t.Parallel()
+
+		for _, args := range [][]string{{"--build", "--json"}, {"--json", "--build"}} {
+			stdout := &bytes.Buffer{}
+			cli := New(Name("gurl"), Version("1.2.3"), Stream(stdout, stdout), VersionCommand())
+			_, err := cli.RunWith(append([]string{"gurl", "version"}, args...))
+			require.NoError(t, err)
+
+			info := VersionInfo{}
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &info), args)
+			assert.NotEmpty(t, info.GoVersion, args)
+		}
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		t.Parallel()
+
+		assert.PanicsWithError(t, "duplicate command: version", func() {
+			New(VersionCommand(), Command("version"))
+		})
+	})
+}
+
+func TestVersion_BuildInfo(t *testing.T) {
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.23.2",
+			Main:      debug.Module{Path: "example.com/gurl", Version: "v0.4.0"},
+			Deps: []*debug.Module{
+				{Path: "example.com/dep", Version: "v1.0.0"},
+				{Path: "example.com/old", Version: "v1.0.0", Replace: &debug.Module{Path: "example.com/new", Version: "v2.0.0"}},
+			},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "abc123"},
+				{Key: "vcs.time", Value: "2024-01-02T03:04:05Z"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+	t.Cleanup(func() { readBuildInfo = debug.ReadBuildInfo })
+
+	stdout := &bytes.Buffer{}
+	cli := New(Name("gurl"), Stream(stdout, stdout), VersionCommand())
+	_, err := cli.RunWith([]string{"gurl", "version", "--build"})
+
+	require.NoError(t, err)
+	assert.Equal(t, `gurl v0.4.0
+revision:     abc123 (modified)
+time:         2024-01-02T03:04:05Z
+go:           go1.23.2
+dependencies:
+  example.com/dep v1.0.0
+  example.com/new v2.0.0
+`, stdout.String())
+}